@@ -0,0 +1,100 @@
+package main
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/game"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/pairing"
+	"executive-chef/internal/player"
+	"executive-chef/internal/recipe"
+	"executive-chef/internal/rng"
+)
+
+// ruleSet is the game content loaded from a directory of YAML files.
+// ingredients.yaml is required; deck.yaml, pairings.yaml, recipes.yaml and
+// payouts.yaml are loaded when present.
+type ruleSet struct {
+	Ingredients []ingredient.Ingredient
+	Deck        deck.Recipe
+	Pairings    *pairing.Table
+	Recipes     *recipe.Book
+	Payouts     game.Payouts
+}
+
+// loadRuleSet reads the content files in dir.
+func loadRuleSet(dir string) (*ruleSet, error) {
+	path := func(name string) string { return filepath.Join(dir, name) }
+	rs := &ruleSet{Deck: deck.Recipe{Size: deck.DefaultSize}, Payouts: game.DefaultPayouts}
+	var err error
+	if rs.Ingredients, err = ingredient.LoadFromFile(path("ingredients.yaml")); err != nil {
+		return nil, err
+	}
+	if exists(path("deck.yaml")) {
+		if rs.Deck, err = deck.LoadRecipe(path("deck.yaml")); err != nil {
+			return nil, err
+		}
+	}
+	if exists(path("pairings.yaml")) {
+		if rs.Pairings, err = pairing.LoadFromFile(path("pairings.yaml"), rs.Ingredients); err != nil {
+			return nil, err
+		}
+	}
+	if exists(path("recipes.yaml")) {
+		if rs.Recipes, err = recipe.LoadFromFile(path("recipes.yaml"), rs.Ingredients); err != nil {
+			return nil, err
+		}
+	}
+	if exists(path("payouts.yaml")) {
+		if rs.Payouts, err = game.LoadPayouts(path("payouts.yaml")); err != nil {
+			return nil, err
+		}
+	}
+	return rs, nil
+}
+
+// newGame builds a single-player game from the rule set with every random
+// choice drawn from r. Games built from the same rule set share no mutable
+// state, so they may be played concurrently. The returned game has no event
+// or action channels yet.
+func (rs *ruleSet) newGame(r *rand.Rand) (*game.Game, error) {
+	return rs.newTable(r, 1)
+}
+
+// newTable builds a game like newGame for the given number of players.
+func (rs *ruleSet) newTable(r *rand.Rand, players int) (*game.Game, error) {
+	d, err := deck.BuildFrom(r, rs.Ingredients, rs.Deck)
+	if err != nil {
+		return nil, err
+	}
+	seats := make([]*player.Player, players)
+	for i := range seats {
+		seats[i] = player.New()
+	}
+	g := game.NewMultiplayer(d, customer.NewDeckFrom(r, rs.Ingredients), seats, nil, nil)
+	g.Pairings = rs.Pairings
+	if rs.Recipes != nil {
+		g.Recipes = recipe.NewBook(rs.Recipes.Recipes)
+	}
+	g.Payouts = rs.Payouts
+	g.Rand = r
+	return g, nil
+}
+
+// newGame builds a game from the content files in the working directory.
+func newGame() (*game.Game, error) {
+	rs, err := loadRuleSet(".")
+	if err != nil {
+		return nil, err
+	}
+	return rs.newGame(rng.Global())
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
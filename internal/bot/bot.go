@@ -0,0 +1,33 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+
+	"executive-chef/internal/headless"
+)
+
+// registry maps bot names to constructors for a fresh strategy.
+var registry = map[string]func() headless.Strategy{
+	"first":     func() headless.Strategy { return &headless.FirstChoice{} },
+	"heuristic": func() headless.Strategy { return NewHeuristic() },
+}
+
+// Names lists the registered bots in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns a fresh instance of the named bot.
+func New(name string) (headless.Strategy, error) {
+	ctor, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown bot %q (available: %v)", name, Names())
+	}
+	return ctor(), nil
+}
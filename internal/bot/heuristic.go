@@ -0,0 +1,400 @@
+package bot
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"executive-chef/internal/consumable"
+	"executive-chef/internal/customer"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/game"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/pairing"
+	"executive-chef/internal/recipe"
+)
+
+// historySize is the number of recent customers the bot remembers when
+// estimating demand.
+const historySize = 30
+
+// Heuristic is a bot that plays competently without looking ahead. It drafts
+// ingredients that cover the cravings of recently seen customers and the
+// dishes already on its menu, designs the dishes expected to earn the most
+// each turn, and deletes its weakest dishes as the menu nears MaxDishes.
+type Heuristic struct {
+	// Payouts is the payment table used to value dishes. The zero value
+	// uses game.DefaultPayouts.
+	Payouts game.Payouts
+
+	player      int
+	phase       game.Phase
+	turn        int
+	drafted     []ingredient.Ingredient
+	dishes      []dishRecord
+	consumables []consumable.Consumable
+	pairings    *pairing.Table
+	recipes     []recipe.Entry
+	seen        []customer.Customer
+	plan        []game.Action
+	finished    bool
+}
+
+// dishRecord tracks how a menu dish has performed since it was created.
+type dishRecord struct {
+	dish    dish.Dish
+	created int
+	earned  int
+}
+
+// NewHeuristic returns a heuristic bot valuing dishes with the default payouts.
+func NewHeuristic() *Heuristic {
+	return &Heuristic{Payouts: game.DefaultPayouts}
+}
+
+// Act implements headless.Strategy.
+func (h *Heuristic) Act(e game.Event) game.Action {
+	switch ev := e.(type) {
+	case game.PhaseEvent:
+		h.phase = ev.Phase
+		h.turn = ev.Turn
+		if ev.Phase == game.PhaseDraft {
+			h.drafted = nil
+		}
+	case game.ConsumableGainedEvent:
+		h.consumables = append(h.consumables, ev.Consumable)
+	case game.ConsumableUsedEvent:
+		if ev.Index >= 0 && ev.Index < len(h.consumables) {
+			h.consumables = append(h.consumables[:ev.Index], h.consumables[ev.Index+1:]...)
+		}
+		if h.phase == game.PhaseDesign {
+			return h.next()
+		}
+	case game.RecipeBookEvent:
+		h.recipes = ev.Entries
+	case game.RecipeDiscoveredEvent:
+		if ev.Index >= 0 && ev.Index < len(h.recipes) {
+			h.recipes[ev.Index] = ev.Entry
+		}
+	case game.DraftOptionsEvent:
+		h.player = ev.Player
+		return game.DraftSelectionAction{Index: h.pickDraft(ev.Reveal)}
+	case game.IngredientDraftedEvent:
+		h.drafted = append(h.drafted, ev.Ingredient)
+	case game.IngredientTransmutedEvent:
+		if ev.Index >= 0 && ev.Index < len(h.drafted) {
+			h.drafted[ev.Index] = ev.To
+		}
+	case game.CustomersPeekedEvent:
+		h.remember(ev.Customers...)
+	case game.DesignOptionsEvent:
+		h.player = ev.Player
+		h.pairings = ev.Pairings
+		h.drafted = append([]ingredient.Ingredient(nil), ev.Drafted...)
+		h.plan = h.planDesign()
+		h.finished = false
+		return h.next()
+	case game.DishCreatedEvent:
+		h.dishes = append(h.dishes, dishRecord{dish: ev.Dish, created: h.turn})
+		return h.next()
+	case game.DishDeletedEvent:
+		if ev.Index >= 0 && ev.Index < len(h.dishes) {
+			h.dishes = append(h.dishes[:ev.Index], h.dishes[ev.Index+1:]...)
+		}
+		return h.next()
+	case game.ServiceResultEvent:
+		h.remember(ev.Customer)
+		// Results are broadcast to every restaurant; only this bot's own
+		// sales count toward its dishes.
+		if ev.Dish != nil && ev.Player == h.player {
+			for i := range h.dishes {
+				if h.dishes[i].dish.Name == ev.Dish.Name {
+					h.dishes[i].earned += ev.Payment
+					break
+				}
+			}
+		}
+		return game.ContinueAction{}
+	case game.ActionRejectedEvent:
+		switch h.phase {
+		case game.PhaseDraft:
+			return game.DraftSelectionAction{Index: 0}
+		case game.PhaseDesign:
+			return h.next()
+		case game.PhaseService:
+			return game.ContinueAction{}
+		}
+	}
+	return nil
+}
+
+// next returns the next planned design action, finishing the phase once the
+// plan is exhausted.
+func (h *Heuristic) next() game.Action {
+	if h.finished {
+		return nil
+	}
+	if len(h.plan) == 0 {
+		h.finished = true
+		return game.FinishDesignAction{}
+	}
+	a := h.plan[0]
+	h.plan = h.plan[1:]
+	return a
+}
+
+// remember records customers for demand estimates, keeping the most recent.
+func (h *Heuristic) remember(cs ...customer.Customer) {
+	h.seen = append(h.seen, cs...)
+	if len(h.seen) > historySize {
+		h.seen = h.seen[len(h.seen)-historySize:]
+	}
+}
+
+// pickDraft returns the index of the most valuable ingredient in reveal.
+func (h *Heuristic) pickDraft(reveal []ingredient.Ingredient) int {
+	best, bestValue := 0, 0.0
+	for i, ing := range reveal {
+		v := h.ingredientValue(ing)
+		if i == 0 || v > bestValue {
+			best, bestValue = i, v
+		}
+	}
+	return best
+}
+
+// ingredientValue estimates how much drafting ing adds this turn.
+func (h *Heuristic) ingredientValue(ing ingredient.Ingredient) float64 {
+	if slices.Contains(h.drafted, ing) {
+		// Dishes only need one copy of each ingredient.
+		return -1
+	}
+	v := 1 + h.demand(ing)
+	switch ing.Role {
+	case ingredient.Protein, ingredient.Carb, ingredient.Vegetable:
+		if !h.hasRole(ing.Role) {
+			v += 2
+		}
+	}
+	for _, d := range h.dishes {
+		if slices.Contains(d.dish.Ingredients, ing) {
+			v += h.performance(d) / float64(len(d.dish.Ingredients))
+		}
+	}
+	for _, other := range h.drafted {
+		if a, ok := h.pairings.Lookup(ing.Name, other.Name); ok {
+			v += float64(a.Score())
+		}
+	}
+	return v
+}
+
+// demand returns the average payout recent customers attach to ing.
+func (h *Heuristic) demand(ing ingredient.Ingredient) float64 {
+	if len(h.seen) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, c := range h.seen {
+		for rank, cr := range c.Cravings {
+			if rank >= len(h.payouts().Ranks) || !slices.Contains(cr.Ingredients, ing) {
+				continue
+			}
+			total += float64(h.payouts().Ranks[rank]) / float64(len(cr.Ingredients))
+		}
+	}
+	return total / float64(len(h.seen))
+}
+
+func (h *Heuristic) hasRole(r ingredient.Role) bool {
+	for _, ing := range h.drafted {
+		if ing.Role == r {
+			return true
+		}
+	}
+	return false
+}
+
+// performance returns a dish's earnings per turn on the menu.
+func (h *Heuristic) performance(d dishRecord) float64 {
+	turns := h.turn - d.created
+	if turns < 1 {
+		turns = 1
+	}
+	return float64(d.earned) / float64(turns)
+}
+
+func (h *Heuristic) payouts() game.Payouts {
+	if len(h.Payouts.Ranks) == 0 {
+		return game.DefaultPayouts
+	}
+	return h.Payouts
+}
+
+// planDesign chooses the actions for this design phase: play a Double if
+// held, delete the weakest dishes if the menu is nearly full, then create
+// the most valuable new dishes.
+func (h *Heuristic) planDesign() []game.Action {
+	var plan []game.Action
+	for i, c := range h.consumables {
+		if c.Kind == consumable.Double {
+			plan = append(plan, game.UseConsumableAction{Index: i})
+			break
+		}
+	}
+
+	creates := h.bestDishes()
+	// Keep one free slot so the menu never sits at the cap.
+	excess := len(h.dishes) + len(creates) - (game.MaxDishes - 1)
+	if excess > 0 {
+		for _, idx := range h.weakestDishes(excess) {
+			plan = append(plan, game.DeleteDishAction{Index: idx})
+		}
+	}
+	for _, c := range creates {
+		plan = append(plan, c)
+	}
+	return plan
+}
+
+// weakestDishes returns the menu indices of the n worst performing dishes
+// created before this turn, highest index first so deletions don't shift
+// the remaining targets.
+func (h *Heuristic) weakestDishes(n int) []int {
+	var idxs []int
+	for i, d := range h.dishes {
+		if d.created < h.turn {
+			idxs = append(idxs, i)
+		}
+	}
+	sort.SliceStable(idxs, func(a, b int) bool {
+		return h.performance(h.dishes[idxs[a]]) < h.performance(h.dishes[idxs[b]])
+	})
+	if n > len(idxs) {
+		n = len(idxs)
+	}
+	idxs = idxs[:n]
+	sort.Sort(sort.Reverse(sort.IntSlice(idxs)))
+	return idxs
+}
+
+// bestDishes greedily picks up to DishesPerTurn new dishes from the drafted
+// ingredients, each chosen for the largest gain in expected service income
+// given the dishes already available this turn.
+func (h *Heuristic) bestDishes() []game.CreateDishAction {
+	var available []dish.Dish
+	existing := make(map[string]bool)
+	for _, d := range h.dishes {
+		existing[key(d.dish.Ingredients)] = true
+		if containsAll(h.drafted, d.dish.Ingredients) {
+			available = append(available, d.dish)
+		}
+	}
+
+	combos := game.Combinations(len(h.drafted), dish.MaxIngredients)
+	var creates []game.CreateDishAction
+	for len(creates) < game.DishesPerTurn {
+		base := h.menuValue(available)
+		bestGain, bestIdx := 0.0, -1
+		for i, idxs := range combos {
+			ings := make([]ingredient.Ingredient, len(idxs))
+			for j, idx := range idxs {
+				ings[j] = h.drafted[idx]
+			}
+			if existing[key(ings)] || hasDuplicates(ings) {
+				continue
+			}
+			gain := h.menuValue(append(available, dish.Dish{Ingredients: ings})) - base
+			if gain > bestGain {
+				bestGain, bestIdx = gain, i
+			}
+		}
+		if bestIdx < 0 {
+			break
+		}
+		idxs := combos[bestIdx]
+		ings := make([]ingredient.Ingredient, len(idxs))
+		names := make([]string, len(idxs))
+		for j, idx := range idxs {
+			ings[j] = h.drafted[idx]
+			names[j] = h.drafted[idx].Name
+		}
+		existing[key(ings)] = true
+		available = append(available, dish.Dish{Ingredients: ings})
+		creates = append(creates, game.CreateDishAction{Name: strings.Join(names, " & "), Indices: idxs})
+	}
+	return creates
+}
+
+// menuValue estimates the income of serving recent customers from dishes.
+// Without any customer history each dish is valued by its size and bonuses.
+func (h *Heuristic) menuValue(dishes []dish.Dish) float64 {
+	if len(h.seen) == 0 {
+		total := 0.0
+		for _, d := range dishes {
+			total += float64(len(d.Ingredients) + h.bonus(d))
+		}
+		return total
+	}
+	total := 0.0
+	for _, c := range h.seen {
+		best := 0
+		for _, d := range dishes {
+			if !c.Accepts(d.Ingredients) {
+				continue
+			}
+			m := h.payouts().Evaluate(c, d.Ingredients)
+			if m.Satisfaction == game.Mismatch {
+				continue
+			}
+			pay := m.Payment + h.bonus(d)
+			if pay < 1 {
+				pay = 1
+			}
+			if pay > best {
+				best = pay
+			}
+		}
+		total += float64(best)
+	}
+	return total / float64(len(h.seen))
+}
+
+// bonus returns the synergy, archetype and known recipe bonuses for d.
+func (h *Heuristic) bonus(d dish.Dish) int {
+	b := h.pairings.Synergy(d.Ingredients) + d.Archetype().Bonus()
+	for _, r := range h.recipes {
+		if r.Discovered && (recipe.Recipe{Name: r.Name, Ingredients: r.Ingredients}).Matches(d.Ingredients) {
+			b += r.Bonus
+		}
+	}
+	return b
+}
+
+// key identifies an ingredient set regardless of order.
+func key(ings []ingredient.Ingredient) string {
+	names := make([]string, len(ings))
+	for i, ing := range ings {
+		names[i] = ing.Name
+	}
+	sort.Strings(names)
+	return strings.Join(names, "+")
+}
+
+func containsAll(have, needed []ingredient.Ingredient) bool {
+	for _, n := range needed {
+		if !slices.Contains(have, n) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasDuplicates(ings []ingredient.Ingredient) bool {
+	for i := range ings {
+		if slices.Contains(ings[:i], ings[i]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+)
+
+var (
+	beef    = ingredient.Ingredient{Name: "Beef", Role: ingredient.Protein}
+	potato  = ingredient.Ingredient{Name: "Potato", Role: ingredient.Carb}
+	carrot  = ingredient.Ingredient{Name: "Carrot", Role: ingredient.Vegetable}
+	spinach = ingredient.Ingredient{Name: "Spinach", Role: ingredient.Vegetable}
+)
+
+func TestPickDraftCoversMissingRoles(t *testing.T) {
+	h := NewHeuristic()
+	h.drafted = []ingredient.Ingredient{carrot}
+	assert.Equal(t, 1, h.pickDraft([]ingredient.Ingredient{spinach, beef, carrot}))
+}
+
+func TestPickDraftFollowsDemand(t *testing.T) {
+	h := NewHeuristic()
+	h.drafted = []ingredient.Ingredient{beef, potato, carrot}
+	h.remember(customer.Customer{Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{spinach}}}})
+	cheese := ingredient.Ingredient{Name: "Cheese", Role: ingredient.Dairy}
+	assert.Equal(t, 1, h.pickDraft([]ingredient.Ingredient{cheese, spinach}))
+}
+
+func TestPlanDesignCreatesBestDishes(t *testing.T) {
+	h := NewHeuristic()
+	h.turn = 1
+	h.drafted = []ingredient.Ingredient{beef, potato, carrot}
+	h.remember(customer.Customer{Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{beef, potato}}}})
+
+	plan := h.planDesign()
+	require.NotEmpty(t, plan)
+	first := plan[0].(game.CreateDishAction)
+	assert.Equal(t, []int{0, 1, 2}, first.Indices, "a full plate earns the craving and the plate bonus")
+	assert.LessOrEqual(t, len(plan), game.DishesPerTurn)
+}
+
+func TestPlanDesignDeletesWeakestDishNearCap(t *testing.T) {
+	h := NewHeuristic()
+	h.turn = 5
+	h.drafted = []ingredient.Ingredient{beef, potato}
+	for i := 0; i < game.MaxDishes-1; i++ {
+		h.dishes = append(h.dishes, dishRecord{
+			dish:    dish.Dish{Name: "Old", Ingredients: []ingredient.Ingredient{{Name: "Old", Role: ingredient.Fruit}}},
+			created: 1,
+			earned:  10,
+		})
+	}
+	h.dishes[3].earned = 0
+
+	plan := h.planDesign()
+	require.NotEmpty(t, plan)
+	assert.Equal(t, game.DeleteDishAction{Index: 3}, plan[0])
+}
+
+func TestHeuristicPlaysFullGame(t *testing.T) {
+	all := []ingredient.Ingredient{beef, potato, carrot, spinach}
+	g := game.New(deck.New(all), customer.NewDeck(all), player.New(), nil, nil)
+	res, err := headless.Run(g, NewHeuristic())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, res.Turns, game.TurnsPerAnte)
+}
+
+func TestNewUnknownBot(t *testing.T) {
+	_, err := New("nope")
+	assert.ErrorContains(t, err, `unknown bot "nope"`)
+	s, err := New("heuristic")
+	require.NoError(t, err)
+	assert.IsType(t, &Heuristic{}, s)
+}
+
+func TestHeuristicCountsOnlyItsOwnSales(t *testing.T) {
+	h := NewHeuristic()
+	h.Act(game.DraftOptionsEvent{Player: 1, Reveal: []ingredient.Ingredient{beef}})
+	stew := dish.Dish{Name: "Beef & Potato", Ingredients: []ingredient.Ingredient{beef, potato}}
+	h.dishes = []dishRecord{{dish: stew}}
+
+	h.Act(game.ServiceResultEvent{Player: 0, Dish: &stew, Payment: 5})
+	assert.Zero(t, h.dishes[0].earned, "a rival's dish of the same name")
+	h.Act(game.ServiceResultEvent{Player: 1, Dish: &stew, Payment: 4})
+	assert.Equal(t, 4, h.dishes[0].earned)
+}
@@ -0,0 +1,190 @@
+// Package bus fans game events out to any number of subscribers, such as
+// the TUI, a recorder or a stats collector. Each subscriber has its own
+// queue and back-pressure policy, so a slow one only holds up the game if
+// it asked to.
+package bus
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"executive-chef/internal/game"
+)
+
+// Policy decides what Publish does when a subscriber's queue is full.
+type Policy int
+
+const (
+	// Block waits until the subscriber makes room. No event is lost, but a
+	// slow subscriber holds up the publisher and every subscriber after it.
+	Block Policy = iota
+	// DropNewest discards the event being published.
+	DropNewest
+	// DropOldest discards the oldest queued event to make room.
+	DropOldest
+	// Disconnect closes the subscription.
+	Disconnect
+)
+
+func (p Policy) String() string {
+	switch p {
+	case Block:
+		return "block"
+	case DropNewest:
+		return "drop-newest"
+	case DropOldest:
+		return "drop-oldest"
+	case Disconnect:
+		return "disconnect"
+	}
+	return "unknown"
+}
+
+// Bus publishes events to its subscribers in the order they subscribed. It
+// is safe for concurrent use.
+type Bus struct {
+	mu     sync.Mutex
+	subs   []*Subscription
+	closed bool
+}
+
+// New returns a bus without subscribers.
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscription receives the events published after it subscribed on C,
+// which is closed once the subscription or the bus is closed.
+type Subscription struct {
+	C <-chan game.Event
+
+	bus     *Bus
+	policy  Policy
+	ch      chan game.Event
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Int64
+
+	// mu guards sends on ch against closing it.
+	mu     sync.Mutex
+	closed bool
+}
+
+// Subscribe adds a subscriber whose queue holds size events, applying
+// policy when it is full. Subscribing to a closed bus returns a closed
+// subscription.
+func (b *Bus) Subscribe(size int, policy Policy) *Subscription {
+	ch := make(chan game.Event, max(size, 0))
+	s := &Subscription{C: ch, bus: b, policy: policy, ch: ch, done: make(chan struct{})}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		s.shut()
+		return s
+	}
+	b.subs = append(b.subs, s)
+	return s
+}
+
+// Publish offers e to every subscriber under its policy. It does nothing
+// once the bus is closed.
+func (b *Bus) Publish(e game.Event) {
+	b.mu.Lock()
+	subs := b.subs
+	b.mu.Unlock()
+	for _, s := range subs {
+		if !s.offer(e) {
+			s.Close()
+		}
+	}
+}
+
+// Forward publishes every event received on events until it is closed.
+// Point a game's Events channel at events to publish the game on the bus.
+func (b *Bus) Forward(events <-chan game.Event) {
+	for e := range events {
+		b.Publish(e)
+	}
+}
+
+// Close closes every subscription. Events published afterwards are
+// discarded.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	subs := b.subs
+	b.subs = nil
+	b.closed = true
+	b.mu.Unlock()
+	for _, s := range subs {
+		s.shut()
+	}
+}
+
+// Close stops the subscription and closes C. A publisher blocked on the
+// subscription moves on.
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	for i, other := range s.bus.subs {
+		if other == s {
+			s.bus.subs = append(s.bus.subs[:i:i], s.bus.subs[i+1:]...)
+			break
+		}
+	}
+	s.bus.mu.Unlock()
+	s.shut()
+}
+
+// Dropped returns the number of events the subscription's policy
+// discarded.
+func (s *Subscription) Dropped() int {
+	return int(s.dropped.Load())
+}
+
+// shut closes the subscription's channels once.
+func (s *Subscription) shut() {
+	s.once.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		s.closed = true
+		close(s.ch)
+		s.mu.Unlock()
+	})
+}
+
+// offer queues e under the subscription's policy. It reports false when the
+// policy is Disconnect and the queue is full.
+func (s *Subscription) offer(e game.Event) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return true
+	}
+	select {
+	case s.ch <- e:
+		return true
+	default:
+	}
+	switch s.policy {
+	case Block:
+		select {
+		case s.ch <- e:
+		case <-s.done:
+		}
+	case DropOldest:
+		select {
+		case <-s.ch:
+			s.dropped.Add(1)
+		default:
+		}
+		select {
+		case s.ch <- e:
+		default:
+			s.dropped.Add(1)
+		}
+	case Disconnect:
+		return false
+	default:
+		s.dropped.Add(1)
+	}
+	return true
+}
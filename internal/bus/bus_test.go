@@ -0,0 +1,127 @@
+package bus_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/bus"
+	"executive-chef/internal/game"
+)
+
+// turn is a distinguishable event.
+func turn(n int) game.Event {
+	return game.PhaseEvent{Turn: n, Phase: game.PhaseDraft}
+}
+
+// drain returns the turns of the events queued on s.
+func drain(s *bus.Subscription) []int {
+	var turns []int
+	for {
+		select {
+		case e, ok := <-s.C:
+			if !ok {
+				return turns
+			}
+			turns = append(turns, e.(game.PhaseEvent).Turn)
+		default:
+			return turns
+		}
+	}
+}
+
+func TestPublishReachesEverySubscriber(t *testing.T) {
+	b := bus.New()
+	first := b.Subscribe(4, bus.Block)
+	second := b.Subscribe(4, bus.DropNewest)
+	b.Publish(turn(1))
+	b.Publish(turn(2))
+	assert.Equal(t, []int{1, 2}, drain(first))
+	assert.Equal(t, []int{1, 2}, drain(second))
+}
+
+func TestDropPolicies(t *testing.T) {
+	b := bus.New()
+	newest := b.Subscribe(2, bus.DropNewest)
+	oldest := b.Subscribe(2, bus.DropOldest)
+	gone := b.Subscribe(2, bus.Disconnect)
+	for i := 1; i <= 4; i++ {
+		b.Publish(turn(i))
+	}
+	assert.Equal(t, []int{1, 2}, drain(newest))
+	assert.Equal(t, 2, newest.Dropped())
+	assert.Equal(t, []int{3, 4}, drain(oldest))
+	assert.Equal(t, 2, oldest.Dropped())
+
+	assert.Equal(t, []int{1, 2}, drain(gone))
+	_, ok := <-gone.C
+	assert.False(t, ok, "disconnected subscription is closed")
+}
+
+func TestBlockWaitsForSubscriber(t *testing.T) {
+	b := bus.New()
+	s := b.Subscribe(1, bus.Block)
+	b.Publish(turn(1))
+	published := make(chan struct{})
+	go func() {
+		b.Publish(turn(2))
+		close(published)
+	}()
+	select {
+	case <-published:
+		t.Fatal("publish did not wait for a full subscriber")
+	case <-time.After(20 * time.Millisecond):
+	}
+	assert.Equal(t, turn(1), <-s.C)
+	<-published
+	assert.Equal(t, turn(2), <-s.C)
+	assert.Zero(t, s.Dropped())
+}
+
+func TestCloseReleasesBlockedPublisher(t *testing.T) {
+	b := bus.New()
+	s := b.Subscribe(0, bus.Block)
+	other := b.Subscribe(1, bus.Block)
+	published := make(chan struct{})
+	go func() {
+		b.Publish(turn(1))
+		close(published)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	s.Close()
+	<-published
+	assert.Equal(t, turn(1), <-other.C)
+
+	b.Publish(turn(2))
+	_, ok := <-s.C
+	assert.False(t, ok)
+}
+
+func TestForwardPublishesChannel(t *testing.T) {
+	b := bus.New()
+	s := b.Subscribe(8, bus.Block)
+	events := make(chan game.Event)
+	done := make(chan struct{})
+	go func() {
+		b.Forward(events)
+		b.Close()
+		close(done)
+	}()
+	events <- turn(1)
+	events <- turn(2)
+	close(events)
+	<-done
+	assert.Equal(t, []int{1, 2}, drain(s))
+	_, ok := <-s.C
+	assert.False(t, ok)
+
+	late := b.Subscribe(1, bus.Block)
+	_, ok = <-late.C
+	assert.False(t, ok, "subscribing to a closed bus")
+}
+
+func TestPolicyString(t *testing.T) {
+	require.Equal(t, "drop-oldest", bus.DropOldest.String())
+}
@@ -0,0 +1,54 @@
+package consumable
+
+import (
+	"math/rand"
+
+	"executive-chef/internal/rng"
+)
+
+// Kind identifies the effect of a consumable card.
+type Kind string
+
+const (
+	// Reroll discards the current draft reveal and reveals a fresh one.
+	Reroll Kind = "Reroll"
+	// Peek shows the customers that will arrive at the next service.
+	Peek Kind = "Peek"
+	// Double doubles the next payment received this turn.
+	Double Kind = "Double"
+	// Transmute turns a drafted ingredient into another of the same role.
+	Transmute Kind = "Transmute"
+)
+
+// Kinds lists every consumable kind.
+var Kinds = []Kind{Reroll, Peek, Double, Transmute}
+
+// Consumable is a one-shot card held by the player until it is used.
+type Consumable struct {
+	Kind Kind
+}
+
+// Description returns a short explanation of the consumable's effect.
+func (c Consumable) Description() string {
+	switch c.Kind {
+	case Reroll:
+		return "reveal a fresh set of draftable ingredients"
+	case Peek:
+		return "see the next customers before service"
+	case Double:
+		return "double the next payment this turn"
+	case Transmute:
+		return "turn a drafted ingredient into another of the same role"
+	}
+	return ""
+}
+
+// Random returns a consumable of a random kind.
+func Random() Consumable {
+	return RandomFrom(rng.Global())
+}
+
+// RandomFrom returns a consumable of a kind drawn from r.
+func RandomFrom(r *rand.Rand) Consumable {
+	return Consumable{Kind: Kinds[r.Intn(len(Kinds))]}
+}
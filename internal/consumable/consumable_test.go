@@ -0,0 +1,19 @@
+package consumable_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"executive-chef/internal/consumable"
+)
+
+func TestRandomReturnsKnownKind(t *testing.T) {
+	rand.Seed(1)
+	for i := 0; i < 20; i++ {
+		c := consumable.Random()
+		assert.Contains(t, consumable.Kinds, c.Kind)
+		assert.NotEmpty(t, c.Description())
+	}
+}
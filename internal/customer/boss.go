@@ -0,0 +1,59 @@
+package customer
+
+import (
+	"executive-chef/internal/ingredient"
+)
+
+// BossRule is a special restriction applied by a boss customer at the end of an ante.
+type BossRule struct {
+	Name        string
+	Description string
+	// RejectRole causes any dish containing an ingredient of this role to be refused.
+	RejectRole ingredient.Role
+	// MinIngredients causes dishes with fewer ingredients to be refused.
+	MinIngredients int
+}
+
+// Bosses lists the boss rules that may appear at the end of an ante.
+var Bosses = []BossRule{
+	{Name: "The Critic", Description: "rejects any dish with a Carb", RejectRole: ingredient.Carb},
+	{Name: "The Carnivore", Description: "rejects any dish with a Vegetable", RejectRole: ingredient.Vegetable},
+	{Name: "The Vegetarian", Description: "rejects any dish with a Protein", RejectRole: ingredient.Protein},
+	{Name: "The Gourmand", Description: "rejects dishes with fewer than three ingredients", MinIngredients: 3},
+}
+
+// Accepts reports whether a dish made of the given ingredients satisfies the rule.
+func (r BossRule) Accepts(ings []ingredient.Ingredient) bool {
+	if len(ings) < r.MinIngredients {
+		return false
+	}
+	if r.RejectRole != "" {
+		for _, ing := range ings {
+			if ing.Role == r.RejectRole {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// RandomBoss generates a boss customer with a random rule. Cravings only use
+// ingredients the rule allows so the boss can always be satisfied.
+func RandomBoss(ingredients []ingredient.Ingredient) Customer {
+	return globalGenerator.boss(ingredients)
+}
+
+func (g generator) boss(ingredients []ingredient.Ingredient) Customer {
+	rule := Bosses[g.r.Intn(len(Bosses))]
+	var allowed []ingredient.Ingredient
+	for _, ing := range ingredients {
+		if rule.RejectRole == "" || ing.Role != rule.RejectRole {
+			allowed = append(allowed, ing)
+		}
+	}
+	cravings := make([]Craving, g.r.Intn(2)+2)
+	for i := range cravings {
+		cravings[i] = g.craving(allowed)
+	}
+	return Customer{Name: rule.Name, Cravings: cravings, Boss: &rule}
+}
@@ -0,0 +1,56 @@
+package customer_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/ingredient"
+)
+
+func TestBossRuleAccepts(t *testing.T) {
+	chicken := ingredient.Ingredient{Name: "Chicken", Role: ingredient.Protein}
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	carrot := ingredient.Ingredient{Name: "Carrot", Role: ingredient.Vegetable}
+
+	critic := customer.BossRule{RejectRole: ingredient.Carb}
+	assert.True(t, critic.Accepts([]ingredient.Ingredient{chicken, carrot}))
+	assert.False(t, critic.Accepts([]ingredient.Ingredient{chicken, rice}))
+
+	gourmand := customer.BossRule{MinIngredients: 3}
+	assert.False(t, gourmand.Accepts([]ingredient.Ingredient{chicken, carrot}))
+	assert.True(t, gourmand.Accepts([]ingredient.Ingredient{chicken, carrot, rice}))
+}
+
+func TestRandomBossCravingsFollowRule(t *testing.T) {
+	rand.Seed(3)
+	ingredients := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein},
+		{Name: "Rice", Role: ingredient.Carb},
+		{Name: "Carrot", Role: ingredient.Vegetable},
+	}
+	for i := 0; i < 10; i++ {
+		b := customer.RandomBoss(ingredients)
+		require.NotNil(t, b.Boss)
+		assert.Equal(t, b.Boss.Name, b.Name)
+		for _, cr := range b.Cravings {
+			for _, ing := range cr.Ingredients {
+				assert.NotEqual(t, b.Boss.RejectRole, ing.Role)
+			}
+		}
+	}
+}
+
+func TestCustomerAcceptsConstraintAndBoss(t *testing.T) {
+	chicken := ingredient.Ingredient{Name: "Chicken", Role: ingredient.Protein}
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	c := customer.Customer{Constraint: &chicken}
+	assert.False(t, c.Accepts([]ingredient.Ingredient{chicken}))
+	assert.True(t, c.Accepts([]ingredient.Ingredient{rice}))
+
+	c = customer.Customer{Boss: &customer.BossRule{RejectRole: ingredient.Carb}}
+	assert.False(t, c.Accepts([]ingredient.Ingredient{rice}))
+}
@@ -2,11 +2,11 @@ package customer
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 
 	"executive-chef/internal/ingredient"
+	"executive-chef/internal/rng"
 )
 
 // Craving represents a combination of ingredients a customer wants.
@@ -19,6 +19,46 @@ type Customer struct {
 	Name       string
 	Cravings   []Craving
 	Constraint *ingredient.Ingredient // ingredient the customer refuses, nil if none
+	Boss       *BossRule              // special rule for boss customers, nil if none
+}
+
+// Accepts reports whether the customer is willing to eat a dish made of the
+// given ingredients, taking their constraint and any boss rule into account.
+func (c Customer) Accepts(ings []ingredient.Ingredient) bool {
+	if c.Constraint != nil {
+		for _, ing := range ings {
+			if ing == *c.Constraint {
+				return false
+			}
+		}
+	}
+	if c.Boss != nil && !c.Boss.Accepts(ings) {
+		return false
+	}
+	return true
+}
+
+// generator draws customers from a random source, naming them with faker.
+// A nil faker uses gofakeit's global source.
+type generator struct {
+	r     *rand.Rand
+	faker *gofakeit.Faker
+}
+
+// globalGenerator draws from the package-level random sources.
+var globalGenerator = generator{r: rng.Global()}
+
+// newGenerator returns a generator whose customers, names included, are
+// fully determined by r.
+func newGenerator(r *rand.Rand) generator {
+	return generator{r: r, faker: gofakeit.New(r.Uint64())}
+}
+
+func (g generator) name() string {
+	if g.faker == nil {
+		return gofakeit.Name()
+	}
+	return g.faker.Name()
 }
 
 // RandomCraving returns a Craving made of random ingredients.
@@ -26,6 +66,10 @@ type Customer struct {
 // provided slice contains duplicates, and no craving will contain more than
 // three ingredients.
 func RandomCraving(ingredients []ingredient.Ingredient) Craving {
+	return globalGenerator.craving(ingredients)
+}
+
+func (g generator) craving(ingredients []ingredient.Ingredient) Craving {
 	if len(ingredients) == 0 {
 		return Craving{}
 	}
@@ -44,8 +88,8 @@ func RandomCraving(ingredients []ingredient.Ingredient) Craving {
 	if limit > 3 {
 		limit = 3
 	}
-	n := rand.Intn(limit) + 1
-	idxs := rand.Perm(len(unique))[:n]
+	n := g.r.Intn(limit) + 1
+	idxs := g.r.Perm(len(unique))[:n]
 	combo := make([]ingredient.Ingredient, 0, n)
 	for _, i := range idxs {
 		combo = append(combo, unique[i])
@@ -56,12 +100,16 @@ func RandomCraving(ingredients []ingredient.Ingredient) Craving {
 // RandomCustomer generates a Customer with the given number of cravings.
 // Cravings are ordered from most to least desired.
 func RandomCustomer(ingredients []ingredient.Ingredient, numCravings int) Customer {
+	return globalGenerator.customer(ingredients, numCravings)
+}
+
+func (g generator) customer(ingredients []ingredient.Ingredient, numCravings int) Customer {
 	if numCravings <= 0 {
 		numCravings = 1
 	}
 	cravings := make([]Craving, numCravings)
 	for i := 0; i < numCravings; i++ {
-		cravings[i] = RandomCraving(ingredients)
+		cravings[i] = g.craving(ingredients)
 	}
 
 	// Choose a constraint from ingredients not already in cravings with 50% chance.
@@ -79,26 +127,25 @@ func RandomCustomer(ingredients []ingredient.Ingredient, numCravings int) Custom
 				candidates = append(candidates, ing)
 			}
 		}
-		if len(candidates) > 0 && rand.Intn(2) == 0 {
-			c := candidates[rand.Intn(len(candidates))]
+		if len(candidates) > 0 && g.r.Intn(2) == 0 {
+			c := candidates[g.r.Intn(len(candidates))]
 			constraint = &c
 		}
 	}
 
-	return Customer{Name: gofakeit.Name(), Cravings: cravings, Constraint: constraint}
+	return Customer{Name: g.name(), Cravings: cravings, Constraint: constraint}
 }
 
 // RandomCustomers generates the specified number of customers.
 func RandomCustomers(ingredients []ingredient.Ingredient, count int) []Customer {
+	return globalGenerator.customers(ingredients, count)
+}
+
+func (g generator) customers(ingredients []ingredient.Ingredient, count int) []Customer {
 	customers := make([]Customer, count)
 	for i := 0; i < count; i++ {
-		numCravings := rand.Intn(3) + 1
-		customers[i] = RandomCustomer(ingredients, numCravings)
+		numCravings := g.r.Intn(3) + 1
+		customers[i] = g.customer(ingredients, numCravings)
 	}
 	return customers
 }
-
-func init() {
-	rand.Seed(time.Now().UnixNano())
-	gofakeit.Seed(time.Now().UnixNano())
-}
@@ -6,17 +6,33 @@ import (
 	"executive-chef/internal/ingredient"
 )
 
-// Deck represents a collection of customer cards.
+// Deck represents a collection of customer cards along with the boss
+// customers that close each ante.
 type Deck struct {
-	Cards []Customer
+	Cards  []Customer
+	Bosses []Customer
 }
 
-// NewDeck creates a deck containing 15 random customers.
+// NewDeck creates a deck containing 15 random customers and 5 bosses.
 // Customers are shuffled upon creation.
 func NewDeck(ingredients []ingredient.Ingredient) *Deck {
-	cards := RandomCustomers(ingredients, 15)
-	rand.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
-	return &Deck{Cards: cards}
+	return globalGenerator.deck(ingredients)
+}
+
+// NewDeckFrom creates a deck like NewDeck, drawing every customer from r so
+// the same seed always yields the same deck.
+func NewDeckFrom(r *rand.Rand, ingredients []ingredient.Ingredient) *Deck {
+	return newGenerator(r).deck(ingredients)
+}
+
+func (g generator) deck(ingredients []ingredient.Ingredient) *Deck {
+	cards := g.customers(ingredients, 15)
+	g.r.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+	bosses := make([]Customer, 5)
+	for i := range bosses {
+		bosses[i] = g.boss(ingredients)
+	}
+	return &Deck{Cards: cards, Bosses: bosses}
 }
 
 // Draw removes up to n customers from the top of the deck and returns them.
@@ -29,3 +45,33 @@ func (d *Deck) Draw(n int) []Customer {
 	d.Cards = d.Cards[n:]
 	return drawn
 }
+
+// Peek returns up to n customers from the top of the deck without removing them.
+func (d *Deck) Peek(n int) []Customer {
+	if n > len(d.Cards) {
+		n = len(d.Cards)
+	}
+	peeked := make([]Customer, n)
+	copy(peeked, d.Cards[:n])
+	return peeked
+}
+
+// DrawBoss removes and returns the next boss customer.
+// The second return value is false if no bosses remain.
+func (d *Deck) DrawBoss() (Customer, bool) {
+	if len(d.Bosses) == 0 {
+		return Customer{}, false
+	}
+	b := d.Bosses[0]
+	d.Bosses = d.Bosses[1:]
+	return b, true
+}
+
+// PeekBoss returns the next boss customer without removing it.
+// The second return value is false if no bosses remain.
+func (d *Deck) PeekBoss() (Customer, bool) {
+	if len(d.Bosses) == 0 {
+		return Customer{}, false
+	}
+	return d.Bosses[0], true
+}
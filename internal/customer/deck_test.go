@@ -10,6 +10,7 @@ import (
 
 	"executive-chef/internal/customer"
 	"executive-chef/internal/ingredient"
+	"executive-chef/internal/rng"
 )
 
 func TestDeckDraw(t *testing.T) {
@@ -23,4 +24,24 @@ func TestDeckDraw(t *testing.T) {
 	drawn := d.Draw(3)
 	assert.Len(t, drawn, 3)
 	assert.Len(t, d.Cards, 12)
+
+	peeked, ok := d.PeekBoss()
+	require.True(t, ok)
+	boss, ok := d.DrawBoss()
+	require.True(t, ok)
+	assert.Equal(t, peeked, boss)
+	assert.NotNil(t, boss.Boss)
+	assert.Len(t, d.Bosses, 4)
+}
+
+func TestNewDeckFromIsReproducible(t *testing.T) {
+	ingredients := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein},
+		{Name: "Rice", Role: ingredient.Carb},
+		{Name: "Broccoli", Role: ingredient.Vegetable},
+	}
+	a := customer.NewDeckFrom(rng.New(3), ingredients)
+	b := customer.NewDeckFrom(rng.New(3), ingredients)
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, customer.NewDeckFrom(rng.New(4), ingredients))
 }
@@ -1,27 +1,133 @@
 package deck
 
 import (
+	"fmt"
 	"math/rand"
-	"time"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
 
 	"executive-chef/internal/ingredient"
+	"executive-chef/internal/rng"
 )
 
+// DefaultSize is the number of cards in a deck built without a recipe.
+const DefaultSize = 50
+
 // Deck represents a collection of ingredient cards.
 type Deck struct {
 	Cards []ingredient.Ingredient
 }
 
+// Recipe describes the composition of a deck. Copies fixes the number of
+// cards for named ingredients; the remaining slots up to Size are filled at
+// random, weighting each ingredient by the weight of its rarity tier. When
+// RarityWeights is empty every ingredient is equally likely.
+type Recipe struct {
+	Size          int                       `yaml:"size"`
+	Copies        map[string]int            `yaml:"copies,omitempty"`
+	RarityWeights map[ingredient.Rarity]int `yaml:"rarity_weights,omitempty"`
+}
+
+// Entry counts the copies of an ingredient in a deck.
+type Entry struct {
+	Ingredient ingredient.Ingredient
+	Count      int
+}
+
 // New creates a new deck containing 50 cards randomly chosen
 // from the provided ingredient list. Ingredients can repeat.
+// An empty ingredient list yields an empty deck.
 func New(all []ingredient.Ingredient) *Deck {
-	rand.Seed(time.Now().UnixNano())
-	cards := make([]ingredient.Ingredient, 50)
-	for i := 0; i < 50; i++ {
-		cards[i] = all[rand.Intn(len(all))]
+	if len(all) == 0 {
+		return &Deck{}
 	}
-	rand.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
-	return &Deck{Cards: cards}
+	d, _ := Build(all, Recipe{Size: DefaultSize})
+	return d
+}
+
+// Build creates a shuffled deck from the ingredient list following the recipe.
+func Build(all []ingredient.Ingredient, r Recipe) (*Deck, error) {
+	return BuildFrom(rng.Global(), all, r)
+}
+
+// BuildFrom creates a deck like Build, drawing cards from rnd so the same
+// seed always yields the same deck.
+func BuildFrom(rnd *rand.Rand, all []ingredient.Ingredient, r Recipe) (*Deck, error) {
+	if r.Size < 0 {
+		return nil, fmt.Errorf("deck size must not be negative, got %d", r.Size)
+	}
+	byName := make(map[string]ingredient.Ingredient, len(all))
+	for _, ing := range all {
+		byName[ing.Name] = ing
+	}
+
+	var cards []ingredient.Ingredient
+	names := make([]string, 0, len(r.Copies))
+	for name := range r.Copies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		n := r.Copies[name]
+		ing, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("deck recipe lists unknown ingredient %q", name)
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("deck recipe has negative copies for %q", name)
+		}
+		for i := 0; i < n; i++ {
+			cards = append(cards, ing)
+		}
+	}
+	if len(cards) > r.Size {
+		return nil, fmt.Errorf("deck recipe lists %d fixed copies but the deck size is %d", len(cards), r.Size)
+	}
+
+	weights := make([]int, len(all))
+	totalWeight := 0
+	for i, ing := range all {
+		w := 1
+		if len(r.RarityWeights) > 0 {
+			w = r.RarityWeights[ing.Rarity.Tier()]
+		}
+		if w < 0 {
+			return nil, fmt.Errorf("deck recipe has negative weight for rarity %q", ing.Rarity.Tier())
+		}
+		weights[i] = w
+		totalWeight += w
+	}
+	if len(cards) < r.Size && totalWeight == 0 {
+		return nil, fmt.Errorf("deck recipe leaves %d slots but no ingredient has a positive weight", r.Size-len(cards))
+	}
+	for len(cards) < r.Size {
+		pick := rnd.Intn(totalWeight)
+		for i, w := range weights {
+			if pick < w {
+				cards = append(cards, all[i])
+				break
+			}
+			pick -= w
+		}
+	}
+
+	rnd.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+	return &Deck{Cards: cards}, nil
+}
+
+// LoadRecipe reads a deck recipe from a YAML file at the given path.
+func LoadRecipe(path string) (Recipe, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Recipe{}, err
+	}
+	r := Recipe{Size: DefaultSize}
+	if err := yaml.Unmarshal(data, &r); err != nil {
+		return Recipe{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
 }
 
 // Draw removes n cards from the top of the deck and returns them.
@@ -34,3 +140,26 @@ func (d *Deck) Draw(n int) []ingredient.Ingredient {
 	d.Cards = d.Cards[n:]
 	return drawn
 }
+
+// Composition counts the cards remaining in the deck per ingredient, ordered
+// from most to least copies and then by name.
+func (d *Deck) Composition() []Entry {
+	counts := make(map[ingredient.Ingredient]int)
+	var entries []Entry
+	for _, c := range d.Cards {
+		if counts[c] == 0 {
+			entries = append(entries, Entry{Ingredient: c})
+		}
+		counts[c]++
+	}
+	for i := range entries {
+		entries[i].Count = counts[entries[i].Ingredient]
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Ingredient.Name < entries[j].Ingredient.Name
+	})
+	return entries
+}
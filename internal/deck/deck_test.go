@@ -1,6 +1,8 @@
 package deck_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,6 +10,7 @@ import (
 
 	"executive-chef/internal/deck"
 	"executive-chef/internal/ingredient"
+	"executive-chef/internal/rng"
 )
 
 func TestNewDeck(t *testing.T) {
@@ -37,3 +40,81 @@ func TestDraw(t *testing.T) {
 	assert.Len(t, drawn, 40)
 	assert.Empty(t, d.Cards)
 }
+
+func TestNewDeckEmptyIngredients(t *testing.T) {
+	d := deck.New(nil)
+	require.NotNil(t, d)
+	assert.Empty(t, d.Cards)
+}
+
+func TestBuildFollowsRecipe(t *testing.T) {
+	all := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein, Rarity: ingredient.Common},
+		{Name: "Rice", Role: ingredient.Carb, Rarity: ingredient.Common},
+		{Name: "Saffron", Role: ingredient.Spice, Rarity: ingredient.Rare},
+	}
+	r := deck.Recipe{
+		Size:          20,
+		Copies:        map[string]int{"Saffron": 2},
+		RarityWeights: map[ingredient.Rarity]int{ingredient.Common: 1, ingredient.Rare: 0},
+	}
+	d, err := deck.Build(all, r)
+	require.NoError(t, err)
+	assert.Len(t, d.Cards, 20)
+
+	counts := map[string]int{}
+	for _, e := range d.Composition() {
+		counts[e.Ingredient.Name] = e.Count
+	}
+	assert.Equal(t, 2, counts["Saffron"])
+	assert.Equal(t, 18, counts["Chicken"]+counts["Rice"])
+}
+
+func TestBuildRejectsBadRecipes(t *testing.T) {
+	all := []ingredient.Ingredient{{Name: "Chicken", Role: ingredient.Protein}}
+	_, err := deck.Build(all, deck.Recipe{Size: 5, Copies: map[string]int{"Tofu": 1}})
+	assert.ErrorContains(t, err, `unknown ingredient "Tofu"`)
+	_, err = deck.Build(all, deck.Recipe{Size: 2, Copies: map[string]int{"Chicken": 3}})
+	assert.ErrorContains(t, err, "deck size is 2")
+	_, err = deck.Build(all, deck.Recipe{Size: 2, RarityWeights: map[ingredient.Rarity]int{ingredient.Rare: 1}})
+	assert.ErrorContains(t, err, "no ingredient has a positive weight")
+}
+
+func TestComposition(t *testing.T) {
+	chicken := ingredient.Ingredient{Name: "Chicken", Role: ingredient.Protein}
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	d := &deck.Deck{Cards: []ingredient.Ingredient{rice, chicken, rice}}
+	assert.Equal(t, []deck.Entry{{Ingredient: rice, Count: 2}, {Ingredient: chicken, Count: 1}}, d.Composition())
+}
+
+func TestLoadRecipe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deck.yaml")
+	data := `size: 30
+copies:
+  Chicken: 4
+rarity_weights:
+  Common: 3
+  Rare: 1
+`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	r, err := deck.LoadRecipe(path)
+	require.NoError(t, err)
+	assert.Equal(t, deck.Recipe{
+		Size:          30,
+		Copies:        map[string]int{"Chicken": 4},
+		RarityWeights: map[ingredient.Rarity]int{ingredient.Common: 3, ingredient.Rare: 1},
+	}, r)
+}
+
+func TestBuildFromIsReproducible(t *testing.T) {
+	all := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein},
+		{Name: "Rice", Role: ingredient.Carb},
+		{Name: "Broccoli", Role: ingredient.Vegetable},
+	}
+	a, err := deck.BuildFrom(rng.New(9), all, deck.Recipe{Size: 20})
+	require.NoError(t, err)
+	b, err := deck.BuildFrom(rng.New(9), all, deck.Recipe{Size: 20})
+	require.NoError(t, err)
+	assert.Equal(t, a.Cards, b.Cards)
+}
@@ -0,0 +1,74 @@
+package dish
+
+import "executive-chef/internal/ingredient"
+
+// Archetype is a recognized dish composition such as a plate or a salad.
+type Archetype string
+
+const (
+	NoArchetype Archetype = ""
+	Plate       Archetype = "Plate"
+	Bowl        Archetype = "Bowl"
+	Salad       Archetype = "Salad"
+)
+
+// archetypeRule recognizes an archetype and the service bonus it earns.
+type archetypeRule struct {
+	archetype Archetype
+	bonus     int
+	matches   func(roles map[ingredient.Role]int, total int) bool
+}
+
+// archetypeRules are checked in order; the first match wins.
+var archetypeRules = []archetypeRule{
+	{
+		archetype: Plate,
+		bonus:     3,
+		matches: func(roles map[ingredient.Role]int, total int) bool {
+			return roles[ingredient.Protein] > 0 && roles[ingredient.Carb] > 0 && roles[ingredient.Vegetable] > 0
+		},
+	},
+	{
+		archetype: Bowl,
+		bonus:     2,
+		matches: func(roles map[ingredient.Role]int, total int) bool {
+			return roles[ingredient.Protein] > 0 && roles[ingredient.Carb] > 0
+		},
+	},
+	{
+		archetype: Salad,
+		bonus:     1,
+		matches: func(roles map[ingredient.Role]int, total int) bool {
+			return total > 1 && roles[ingredient.Vegetable] == total
+		},
+	},
+}
+
+// Classify returns the archetype of a dish made of the given ingredients.
+func Classify(ings []ingredient.Ingredient) Archetype {
+	roles := make(map[ingredient.Role]int)
+	for _, ing := range ings {
+		roles[ing.Role]++
+	}
+	for _, r := range archetypeRules {
+		if r.matches(roles, len(ings)) {
+			return r.archetype
+		}
+	}
+	return NoArchetype
+}
+
+// Bonus returns the service payment bonus for serving a dish of this archetype.
+func (a Archetype) Bonus() int {
+	for _, r := range archetypeRules {
+		if r.archetype == a {
+			return r.bonus
+		}
+	}
+	return 0
+}
+
+// Archetype returns the recognized archetype of the dish.
+func (d Dish) Archetype() Archetype {
+	return Classify(d.Ingredients)
+}
@@ -0,0 +1,33 @@
+package dish_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+)
+
+func TestClassify(t *testing.T) {
+	beef := ingredient.Ingredient{Name: "Beef", Role: ingredient.Protein}
+	potato := ingredient.Ingredient{Name: "Potato", Role: ingredient.Carb}
+	carrot := ingredient.Ingredient{Name: "Carrot", Role: ingredient.Vegetable}
+	spinach := ingredient.Ingredient{Name: "Spinach", Role: ingredient.Vegetable}
+
+	cases := []struct {
+		ings []ingredient.Ingredient
+		want dish.Archetype
+	}{
+		{[]ingredient.Ingredient{beef, potato, carrot}, dish.Plate},
+		{[]ingredient.Ingredient{beef, potato}, dish.Bowl},
+		{[]ingredient.Ingredient{carrot, spinach}, dish.Salad},
+		{[]ingredient.Ingredient{carrot}, dish.NoArchetype},
+		{[]ingredient.Ingredient{beef, carrot}, dish.NoArchetype},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, dish.Classify(c.ings))
+	}
+	assert.Equal(t, 3, dish.Plate.Bonus())
+	assert.Equal(t, 0, dish.NoArchetype.Bonus())
+}
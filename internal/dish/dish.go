@@ -10,3 +10,21 @@ type Dish struct {
 	Name        string
 	Ingredients []ingredient.Ingredient
 }
+
+// Cost returns the combined cost of the dish's ingredients.
+func (d Dish) Cost() int {
+	total := 0
+	for _, ing := range d.Ingredients {
+		total += ing.Cost
+	}
+	return total
+}
+
+// Flavor returns the combined flavor profile of the dish's ingredients.
+func (d Dish) Flavor() ingredient.Flavor {
+	var f ingredient.Flavor
+	for _, ing := range d.Ingredients {
+		f = f.Add(ing.Flavor)
+	}
+	return f
+}
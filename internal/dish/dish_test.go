@@ -0,0 +1,19 @@
+package dish_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+)
+
+func TestDishCostAndFlavor(t *testing.T) {
+	d := dish.Dish{Name: "Salmon Rice", Ingredients: []ingredient.Ingredient{
+		{Name: "Salmon", Role: ingredient.Protein, Cost: 4, Flavor: ingredient.Flavor{Salty: 1, Umami: 3}},
+		{Name: "Rice", Role: ingredient.Carb, Cost: 1, Flavor: ingredient.Flavor{Sweet: 1}},
+	}}
+	assert.Equal(t, 5, d.Cost())
+	assert.Equal(t, ingredient.Flavor{Salty: 1, Sweet: 1, Umami: 3}, d.Flavor())
+}
@@ -0,0 +1,25 @@
+// Package engine plays games as a pure state machine. Each call takes a game
+// state and returns the next one, leaving the state it was given untouched,
+// so callers can keep earlier states to branch from or compare against.
+package engine
+
+import "executive-chef/internal/game"
+
+// Start returns a started copy of g and the events up to the first decision.
+func Start(g *game.Game) (*game.Game, []game.Event) {
+	next := g.Clone()
+	return next, next.Start()
+}
+
+// Apply returns the state after action a is played in s, together with the
+// events that followed, as game.Apply does. s itself is not changed.
+func Apply(s *game.Game, a game.Action) (*game.Game, []game.Event, error) {
+	next := s.Clone()
+	_, events, err := game.Apply(next, a)
+	return next, events, err
+}
+
+// LegalActions lists every action the rules allow next in s.
+func LegalActions(s *game.Game) []game.Action {
+	return game.LegalActions(s)
+}
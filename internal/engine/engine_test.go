@@ -0,0 +1,56 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/engine"
+	"executive-chef/internal/game"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+	"executive-chef/internal/rng"
+)
+
+func newGame(seed int64) *game.Game {
+	all := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein},
+		{Name: "Tofu", Role: ingredient.Protein},
+		{Name: "Rice", Role: ingredient.Carb},
+		{Name: "Broccoli", Role: ingredient.Vegetable},
+	}
+	r := rng.New(seed)
+	d, _ := deck.BuildFrom(r, all, deck.Recipe{Size: deck.DefaultSize})
+	players := []*player.Player{player.New(), player.New()}
+	g := game.NewMultiplayer(d, customer.NewDeckFrom(r, all), players, nil, nil)
+	g.Rand = r
+	return g
+}
+
+func TestStartLeavesGameUnstarted(t *testing.T) {
+	g := newGame(1)
+	s, events := engine.Start(g)
+	assert.NotEmpty(t, events)
+	assert.NotEmpty(t, engine.LegalActions(s))
+	assert.Empty(t, engine.LegalActions(g))
+}
+
+func TestApplyLeavesStateUntouched(t *testing.T) {
+	s, _ := engine.Start(newGame(1))
+	for i := 0; i < 60 && !s.Over(); i++ {
+		a := engine.LegalActions(s)[0]
+		before := s.Clone()
+		next, events, err := engine.Apply(s, a)
+		require.NoError(t, err)
+		assert.Equal(t, before, s, "step %d", i)
+
+		again, replayed, err := engine.Apply(s, a)
+		require.NoError(t, err)
+		assert.Equal(t, events, replayed, "step %d", i)
+		assert.Equal(t, next, again, "step %d", i)
+		s = next
+	}
+}
@@ -0,0 +1,102 @@
+package env
+
+import (
+	"strings"
+
+	"executive-chef/internal/consumable"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/game"
+	"executive-chef/internal/player"
+)
+
+// The action table is laid out in blocks, in this order:
+//
+//	draft      game.PackSize picks, one per card of the pack
+//	dish       one per set of up to dish.MaxIngredients drafted slots,
+//	           in the order of game.Combinations
+//	delete     game.MaxDishes deletions, one per menu slot
+//	finish     one FinishDesignAction
+//	continue   one ContinueAction
+//	consumable player.MaxConsumables × game.MaxPicks uses, one per hand
+//	           slot and drafted target; only Transmute takes a target
+//	           other than 0
+var (
+	dishSets = game.Combinations(game.MaxPicks, dish.MaxIngredients)
+
+	draftStart      = 0
+	dishStart       = draftStart + game.PackSize
+	deleteStart     = dishStart + len(dishSets)
+	finishAction    = deleteStart + game.MaxDishes
+	continueAction  = finishAction + 1
+	consumableStart = continueAction + 1
+
+	// ActionSize is the number of actions, legal or not.
+	ActionSize = consumableStart + player.MaxConsumables*game.MaxPicks
+
+	table = newTable()
+)
+
+// newTable lists the game action behind every index. Dishes get their name
+// when they are taken.
+func newTable() []game.Action {
+	t := make([]game.Action, 0, ActionSize)
+	for i := 0; i < game.PackSize; i++ {
+		t = append(t, game.DraftSelectionAction{Index: i})
+	}
+	for _, set := range dishSets {
+		t = append(t, game.CreateDishAction{Indices: set})
+	}
+	for i := 0; i < game.MaxDishes; i++ {
+		t = append(t, game.DeleteDishAction{Index: i})
+	}
+	t = append(t, game.FinishDesignAction{}, game.ContinueAction{})
+	for i := 0; i < player.MaxConsumables; i++ {
+		for target := 0; target < game.MaxPicks; target++ {
+			t = append(t, game.UseConsumableAction{Index: i, Target: target})
+		}
+	}
+	return t
+}
+
+// mask lists the legal actions in s, following s.Mask.
+func mask(s game.State) []bool {
+	m := s.Mask()
+	out := make([]bool, ActionSize)
+	for i, ok := range m.Draft {
+		if i < game.PackSize {
+			out[draftStart+i] = ok
+		}
+	}
+	for i, set := range dishSets {
+		out[dishStart+i] = m.CreateDish && set[len(set)-1] < len(s.Drafted)
+	}
+	for i, ok := range m.DeleteDish {
+		if i < game.MaxDishes {
+			out[deleteStart+i] = ok
+		}
+	}
+	out[finishAction] = m.FinishDesign
+	out[continueAction] = m.Continue
+	for i, ok := range m.UseConsumable {
+		if !ok || i >= player.MaxConsumables {
+			continue
+		}
+		targets := 1
+		if s.Consumables[i].Kind == consumable.Transmute {
+			targets = min(len(s.Drafted), game.MaxPicks)
+		}
+		for target := 0; target < targets; target++ {
+			out[consumableStart+i*game.MaxPicks+target] = true
+		}
+	}
+	return out
+}
+
+// dishName names a dish after its ingredients, the way State.LegalActions does.
+func dishName(s game.State, indices []int) string {
+	names := make([]string, len(indices))
+	for i, idx := range indices {
+		names[i] = s.Drafted[idx].Name
+	}
+	return strings.Join(names, ", ")
+}
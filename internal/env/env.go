@@ -0,0 +1,140 @@
+// Package env wraps a single-player game in a gym-style environment for
+// learning-based bots: Reset starts a seeded game, Step takes one action and
+// returns the next observation, the reward and whether the game is over.
+//
+// Actions are integers below ActionSize, indexing a fixed table of draft
+// picks, dishes, deletions, consumables and the finish and continue actions.
+// Observations are fixed-size vectors of float64; see Env.Observe for their
+// layout.
+package env
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"executive-chef/internal/game"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/rng"
+)
+
+// Config describes the games an Env plays.
+type Config struct {
+	// Ingredients is the vocabulary observations encode ingredients
+	// against. Ingredients missing from it are left out of observations.
+	Ingredients []ingredient.Ingredient
+	// NewGame builds a single-player game drawing every random choice from r.
+	NewGame func(r *rand.Rand) (*game.Game, error)
+}
+
+// Env is a gym-style environment playing one game at a time. It is not safe
+// for concurrent use.
+type Env struct {
+	cfg   Config
+	vocab map[string]int
+
+	game  *game.Game
+	state game.State
+	done  bool
+}
+
+// New returns an environment for the configured games. Call Reset before
+// the first Step.
+func New(cfg Config) *Env {
+	vocab := make(map[string]int, len(cfg.Ingredients))
+	for i, ing := range cfg.Ingredients {
+		vocab[ing.Name] = i
+	}
+	return &Env{cfg: cfg, vocab: vocab, done: true}
+}
+
+// Reset abandons any game in progress, starts a new one with the given seed
+// and returns the observation of its first decision. The same seed always
+// yields the same game.
+func (e *Env) Reset(seed int64) ([]float64, error) {
+	g, err := e.cfg.NewGame(rng.New(seed))
+	if err != nil {
+		return nil, err
+	}
+	if len(g.Players) != 1 {
+		return nil, fmt.Errorf("env: game has %d players, want 1", len(g.Players))
+	}
+	e.game = g
+	e.state = game.State{}
+	e.done = false
+	e.absorb(g.Start())
+	return e.Observe(), nil
+}
+
+// Step takes the action with the given index and returns the observation
+// of the next decision, the money earned meanwhile as the reward, and
+// whether the game is over. Actions the mask rules out are refused with an
+// error and leave the game as it was.
+func (e *Env) Step(action int) ([]float64, float64, bool, error) {
+	if e.done {
+		return nil, 0, true, errors.New("env: game is over, call Reset")
+	}
+	a, err := e.Action(action)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	money := e.state.Money
+	_, events, err := game.Apply(e.game, a)
+	if err != nil && !errors.Is(err, game.ErrRejected) {
+		return nil, 0, false, err
+	}
+	e.absorb(events)
+	return e.Observe(), float64(e.state.Money - money), e.done, nil
+}
+
+// Action returns the game action the index stands for in the current
+// state, or an error if the mask rules it out.
+func (e *Env) Action(action int) (game.Action, error) {
+	if action < 0 || action >= ActionSize {
+		return nil, fmt.Errorf("env: action %d out of range [0, %d)", action, ActionSize)
+	}
+	if e.done || !e.Mask()[action] {
+		return nil, fmt.Errorf("env: action %d is not legal now", action)
+	}
+	a := table[action]
+	if c, ok := a.(game.CreateDishAction); ok {
+		c.Name = dishName(e.state, c.Indices)
+		a = c
+	}
+	return a, nil
+}
+
+// Mask reports which of the ActionSize actions are legal now. Every action
+// is ruled out once the game is over. The engine may still reject a
+// consumable it cannot apply, such as a Transmute with nothing to turn the
+// ingredient into; the step then earns nothing and the state is unchanged.
+func (e *Env) Mask() []bool {
+	if e.done {
+		return make([]bool, ActionSize)
+	}
+	return mask(e.state)
+}
+
+// State returns the state of the current decision.
+func (e *Env) State() game.State {
+	return e.state
+}
+
+// Done reports whether the game is over.
+func (e *Env) Done() bool {
+	return e.done
+}
+
+// absorb keeps the latest snapshot from events and notes the end of the
+// game.
+func (e *Env) absorb(events []game.Event) {
+	for _, ev := range events {
+		switch ev := ev.(type) {
+		case game.StateSnapshotEvent:
+			e.state = ev.State
+		case game.GameOverEvent:
+			e.state.Money = ev.Money
+			e.done = true
+		}
+	}
+}
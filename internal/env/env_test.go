@@ -0,0 +1,162 @@
+package env_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/env"
+	"executive-chef/internal/game"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+)
+
+var all = []ingredient.Ingredient{
+	{Name: "Chicken", Role: ingredient.Protein},
+	{Name: "Tofu", Role: ingredient.Protein},
+	{Name: "Rice", Role: ingredient.Carb},
+	{Name: "Noodles", Role: ingredient.Carb},
+	{Name: "Broccoli", Role: ingredient.Vegetable},
+	{Name: "Carrot", Role: ingredient.Vegetable},
+}
+
+func newEnv(players int) *env.Env {
+	return env.New(env.Config{
+		Ingredients: all,
+		NewGame: func(r *rand.Rand) (*game.Game, error) {
+			d, err := deck.BuildFrom(r, all, deck.Recipe{Size: deck.DefaultSize})
+			if err != nil {
+				return nil, err
+			}
+			seats := make([]*player.Player, players)
+			for i := range seats {
+				seats[i] = player.New()
+			}
+			g := game.NewMultiplayer(d, customer.NewDeckFrom(r, all), seats, nil, nil)
+			g.Rand = r
+			return g, nil
+		},
+	})
+}
+
+// legal returns the indices of the legal actions in mask.
+func legal(mask []bool) []int {
+	var out []int
+	for i, ok := range mask {
+		if ok {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+func TestResetStartsWithDraft(t *testing.T) {
+	e := newEnv(1)
+	obs, err := e.Reset(1)
+	require.NoError(t, err)
+	assert.Len(t, obs, e.ObservationSize())
+	assert.Equal(t, game.PhaseDraft, e.State().Phase)
+	assert.False(t, e.Done())
+
+	mask := e.Mask()
+	require.Len(t, mask, env.ActionSize)
+	picks := legal(mask)
+	assert.Len(t, picks, len(e.State().Reveal)+countUsable(e.State()))
+	assert.True(t, mask[0])
+}
+
+func countUsable(s game.State) int {
+	n := 0
+	for i := range s.Consumables {
+		if s.Usable(i) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestStepRefusesIllegalActions(t *testing.T) {
+	e := newEnv(1)
+	_, _, _, err := e.Step(0)
+	assert.Error(t, err, "step before reset")
+
+	_, err = e.Reset(1)
+	require.NoError(t, err)
+	_, _, _, err = e.Step(env.ActionSize)
+	assert.Error(t, err)
+	mask := e.Mask()
+	for i, ok := range mask {
+		if !ok {
+			_, _, _, err = e.Step(i)
+			assert.Error(t, err)
+			break
+		}
+	}
+	assert.Equal(t, game.PhaseDraft, e.State().Phase)
+}
+
+// play takes random legal actions until the game is over and returns every
+// observation and the total reward.
+func play(t *testing.T, e *env.Env, seed int64) ([][]float64, float64) {
+	t.Helper()
+	r := rand.New(rand.NewSource(seed))
+	obs, err := e.Reset(seed)
+	require.NoError(t, err)
+	seen := [][]float64{obs}
+	total := 0.0
+	for steps := 0; !e.Done(); steps++ {
+		require.Less(t, steps, 10000, "game never ended")
+		options := legal(e.Mask())
+		require.NotEmpty(t, options)
+		obs, reward, done, err := e.Step(options[r.Intn(len(options))])
+		require.NoError(t, err)
+		require.Len(t, obs, e.ObservationSize())
+		assert.Equal(t, e.Done(), done)
+		seen = append(seen, obs)
+		total += reward
+	}
+	return seen, total
+}
+
+func TestStepPlaysFullGame(t *testing.T) {
+	e := newEnv(1)
+	_, total := play(t, e, 3)
+	assert.Equal(t, float64(e.State().Money), total)
+	assert.Empty(t, legal(e.Mask()))
+	_, _, done, err := e.Step(0)
+	assert.True(t, done)
+	assert.Error(t, err)
+}
+
+func TestSameSeedSameGame(t *testing.T) {
+	e := newEnv(1)
+	first, _ := play(t, e, 5)
+	second, _ := play(t, e, 5)
+	assert.Equal(t, first, second)
+}
+
+func TestResetAbandonsGameInProgress(t *testing.T) {
+	e := newEnv(1)
+	_, err := e.Reset(1)
+	require.NoError(t, err)
+	_, _, _, err = e.Step(0)
+	require.NoError(t, err)
+	obs, err := e.Reset(2)
+	require.NoError(t, err)
+	assert.Len(t, obs, e.ObservationSize())
+	assert.Equal(t, 1, e.State().Turn)
+}
+
+func TestResetNeedsOnePlayer(t *testing.T) {
+	_, err := newEnv(2).Reset(1)
+	assert.Error(t, err)
+
+	e := env.New(env.Config{NewGame: func(*rand.Rand) (*game.Game, error) { return nil, errors.New("no rules") }})
+	_, err = e.Reset(1)
+	assert.EqualError(t, err, "no rules")
+}
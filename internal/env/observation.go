@@ -0,0 +1,133 @@
+package env
+
+import (
+	"executive-chef/internal/consumable"
+	"executive-chef/internal/customer"
+	"executive-chef/internal/game"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+)
+
+const (
+	// scalars is the number of leading single values in an observation.
+	scalars = 13
+	// maxCravings is the number of cravings encoded per customer; customers
+	// have at most three.
+	maxCravings = 3
+	// customerSlots holds the customers peeked at and the one just served.
+	customerSlots = game.CustomersPerTurn + 1
+)
+
+// ObservationSize is the length of every observation.
+func (e *Env) ObservationSize() int {
+	v := len(e.cfg.Ingredients)
+	return scalars +
+		game.PackSize*v +
+		game.MaxPicks*v +
+		player.MaxConsumables*len(consumable.Kinds) +
+		game.MaxDishes*(v+3) +
+		customerSlots*(2+(maxCravings+1)*v)
+}
+
+// Observe encodes the current state as a vector of ObservationSize values.
+// With V ingredients in the vocabulary it holds, in order:
+//
+//   - turn, ante, turns per ante, ante target, money, picks left, dishes
+//     left, waiting, double next, boss ante, and a one-hot of the phase
+//   - the draft pack, game.PackSize slots of a V one-hot each
+//   - the drafted ingredients, game.MaxPicks slots of a V one-hot each
+//   - the hand, player.MaxConsumables slots of a one-hot of consumable.Kinds
+//   - the menu, game.MaxDishes slots of a V multi-hot of the dish's
+//     ingredients followed by how many of them were drafted, whether the
+//     dish is available and whether it is new
+//   - the visible customers, the ones peeked at followed by the one just
+//     served: whether the slot is filled, whether it is a boss, a V multi-hot
+//     per craving in order and a V one-hot of the ingredient refused
+//
+// Empty slots are all zeros. Flags are 1 or 0.
+func (e *Env) Observe() []float64 {
+	s := e.state
+	obs := make([]float64, 0, e.ObservationSize())
+	obs = append(obs,
+		float64(s.Turn), float64(s.Ante), float64(s.AnteTurns), float64(s.Target),
+		float64(s.Money), float64(s.Picks), float64(s.DishesLeft),
+		flag(s.Waiting), flag(s.DoubleNext), flag(s.Boss != nil),
+		flag(s.Phase == game.PhaseDraft), flag(s.Phase == game.PhaseDesign), flag(s.Phase == game.PhaseService),
+	)
+	for i := 0; i < game.PackSize; i++ {
+		obs = e.slot(obs, s.Reveal, i)
+	}
+	for i := 0; i < game.MaxPicks; i++ {
+		obs = e.slot(obs, s.Drafted, i)
+	}
+	for i := 0; i < player.MaxConsumables; i++ {
+		for _, k := range consumable.Kinds {
+			obs = append(obs, flag(i < len(s.Consumables) && s.Consumables[i].Kind == k))
+		}
+	}
+	for i := 0; i < game.MaxDishes; i++ {
+		if i >= len(s.Menu) {
+			obs = append(obs, make([]float64, len(e.cfg.Ingredients)+3)...)
+			continue
+		}
+		item := s.Menu[i]
+		obs = e.hot(obs, item.Dish.Ingredients)
+		obs = append(obs, float64(item.Have), flag(item.Available()), flag(item.New))
+	}
+	visible := s.Peeked
+	if s.Result != nil {
+		visible = append(visible[:len(visible):len(visible)], s.Result.Customer)
+	}
+	for i := 0; i < customerSlots; i++ {
+		if i >= len(visible) {
+			obs = append(obs, make([]float64, 2+(maxCravings+1)*len(e.cfg.Ingredients))...)
+			continue
+		}
+		obs = e.customer(obs, visible[i])
+	}
+	return obs
+}
+
+// slot appends a one-hot of ings[i], or zeros past the end of ings.
+func (e *Env) slot(obs []float64, ings []ingredient.Ingredient, i int) []float64 {
+	if i >= len(ings) {
+		return append(obs, make([]float64, len(e.cfg.Ingredients))...)
+	}
+	return e.hot(obs, ings[i:i+1])
+}
+
+// hot appends a multi-hot of ings over the vocabulary.
+func (e *Env) hot(obs []float64, ings []ingredient.Ingredient) []float64 {
+	start := len(obs)
+	obs = append(obs, make([]float64, len(e.cfg.Ingredients))...)
+	for _, ing := range ings {
+		if i, ok := e.vocab[ing.Name]; ok {
+			obs[start+i] = 1
+		}
+	}
+	return obs
+}
+
+// customer appends the encoding of one visible customer.
+func (e *Env) customer(obs []float64, c customer.Customer) []float64 {
+	obs = append(obs, 1, flag(c.Boss != nil))
+	for i := 0; i < maxCravings; i++ {
+		var ings []ingredient.Ingredient
+		if i < len(c.Cravings) {
+			ings = c.Cravings[i].Ingredients
+		}
+		obs = e.hot(obs, ings)
+	}
+	var refused []ingredient.Ingredient
+	if c.Constraint != nil {
+		refused = append(refused, *c.Constraint)
+	}
+	return e.hot(obs, refused)
+}
+
+func flag(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
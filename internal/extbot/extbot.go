@@ -0,0 +1,235 @@
+package extbot
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"executive-chef/internal/game"
+)
+
+// DefaultTimeout is how long a bot has to answer each decision unless
+// configured otherwise.
+const DefaultTimeout = time.Second
+
+// maxStrikes is the number of rejected actions in a row after which a
+// decision is settled with the first legal action instead of asking again,
+// so a bot that keeps sending bad actions cannot stall the game.
+const maxStrikes = 3
+
+// maxLine is the longest reply accepted from a bot.
+const maxLine = 1 << 20
+
+// Bot is a headless.Strategy played by an external program.
+type Bot struct {
+	timeout time.Duration
+	w       *bufio.Writer
+	replies chan reply
+	closer  func() error
+
+	mu      sync.Mutex
+	err     error
+	next    int
+	strikes int
+}
+
+// New returns a bot that writes messages to w and reads replies from r.
+// timeout bounds each decision; zero uses DefaultTimeout.
+func New(r io.Reader, w io.Writer, timeout time.Duration) *Bot {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	b := &Bot{timeout: timeout, w: bufio.NewWriter(w), replies: make(chan reply)}
+	go b.read(r)
+	b.send(hello{Type: "hello", Protocol: Protocol, TimeoutMS: timeout.Milliseconds()})
+	return b
+}
+
+// Start runs the program named by command[0] with the remaining arguments
+// and returns a bot talking to it. The program's standard error is passed
+// through. Close stops it.
+func Start(command []string, timeout time.Duration) (*Bot, error) {
+	if len(command) == 0 {
+		return nil, errors.New("extbot: no command")
+	}
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("extbot: starting %s: %w", command[0], err)
+	}
+	b := New(stdout, stdin, timeout)
+	b.closer = func() error {
+		stdin.Close()
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(b.timeout):
+			cmd.Process.Kill()
+			return <-done
+		}
+	}
+	return b, nil
+}
+
+// Close stops a program started with Start. It does nothing for bots made
+// with New.
+func (b *Bot) Close() error {
+	if b.closer == nil {
+		return nil
+	}
+	return b.closer()
+}
+
+// Err returns the first error talking to the program, if any. Decisions are
+// settled with the first legal action once the program is unreachable.
+func (b *Bot) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
+
+func (b *Bot) fail(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Act implements headless.Strategy. Every event is forwarded to the
+// program, and snapshots that leave the player something to do are turned
+// into decisions.
+func (b *Bot) Act(e game.Event) game.Action {
+	data, err := game.MarshalEvent(e)
+	if err == nil {
+		b.send(event{Type: "event", Event: data})
+	}
+	switch ev := e.(type) {
+	case game.ActionRejectedEvent:
+		b.strikes++
+		return nil
+	case game.StateSnapshotEvent:
+		legal := ev.LegalActions()
+		if len(legal) == 0 {
+			return nil
+		}
+		for i, a := range legal {
+			legal[i] = game.ForPlayer(a, ev.Player)
+		}
+		if b.strikes >= maxStrikes {
+			b.strikes = 0
+			return legal[0]
+		}
+		return b.decide(ev, legal)
+	default:
+		b.strikes = 0
+		return nil
+	}
+}
+
+// decide asks the program to choose from legal, falling back to legal[0].
+func (b *Bot) decide(s game.StateSnapshotEvent, legal []game.Action) game.Action {
+	b.next++
+	msg := decide{Type: "decide", ID: b.next, Player: s.Player, State: s.State, Mask: s.Mask()}
+	for _, a := range legal {
+		data, err := game.MarshalAction(a)
+		if err != nil {
+			return legal[0]
+		}
+		msg.Legal = append(msg.Legal, data)
+	}
+	if !b.send(msg) {
+		return legal[0]
+	}
+	deadline := time.NewTimer(b.timeout)
+	defer deadline.Stop()
+	for {
+		select {
+		case r, ok := <-b.replies:
+			if !ok {
+				return legal[0]
+			}
+			if r.ID != b.next {
+				continue
+			}
+			if a, ok := choose(r, legal); ok {
+				return a
+			}
+			return legal[0]
+		case <-deadline.C:
+			return legal[0]
+		}
+	}
+}
+
+// choose returns the action a reply picks from legal.
+func choose(r reply, legal []game.Action) (game.Action, bool) {
+	if r.Index != nil {
+		if *r.Index < 0 || *r.Index >= len(legal) {
+			return nil, false
+		}
+		return legal[*r.Index], true
+	}
+	if len(r.Action) == 0 {
+		return nil, false
+	}
+	a, err := game.UnmarshalAction(r.Action)
+	if err != nil {
+		return nil, false
+	}
+	return a, true
+}
+
+// send writes one message line, reporting whether it reached the program.
+func (b *Bot) send(v any) bool {
+	if b.Err() != nil {
+		return false
+	}
+	data, err := json.Marshal(v)
+	if err == nil {
+		b.w.Write(data)
+		b.w.WriteByte('\n')
+		err = b.w.Flush()
+	}
+	if err != nil {
+		b.fail(fmt.Errorf("extbot: writing to bot: %w", err))
+		return false
+	}
+	return true
+}
+
+// read passes the program's replies to decide until its output ends.
+// Replies that can't be decoded are dropped.
+func (b *Bot) read(r io.Reader) {
+	defer close(b.replies)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, maxLine)
+	for sc.Scan() {
+		var rep reply
+		if err := json.Unmarshal(sc.Bytes(), &rep); err != nil {
+			continue
+		}
+		b.replies <- rep
+	}
+	if err := sc.Err(); err != nil {
+		b.fail(fmt.Errorf("extbot: reading from bot: %w", err))
+	} else {
+		b.fail(errors.New("extbot: bot closed its output"))
+	}
+}
@@ -0,0 +1,182 @@
+package extbot_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/extbot"
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+)
+
+// TestMain lets the test binary double as an external bot that always
+// answers with the first legal action.
+func TestMain(m *testing.M) {
+	if os.Getenv("EXTBOT_TEST_BOT") == "1" {
+		sc := bufio.NewScanner(os.Stdin)
+		sc.Buffer(nil, 1<<20)
+		for sc.Scan() {
+			var msg struct {
+				Type string
+				ID   int
+			}
+			if json.Unmarshal(sc.Bytes(), &msg) == nil && msg.Type == "decide" {
+				fmt.Printf("{\"id\":%d,\"index\":0}\n", msg.ID)
+			}
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// message is a line written by a bot, decoded loosely.
+type message struct {
+	Type     string
+	Protocol int
+	ID       int
+	Player   int
+	Mask     game.Mask
+	Legal    []json.RawMessage
+}
+
+// fake connects a Bot to pipes the test plays the program's end of.
+func fake(t *testing.T, timeout time.Duration) (*extbot.Bot, *bufio.Scanner, io.Writer) {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	t.Cleanup(func() { inW.Close(); outW.Close() })
+	sc := bufio.NewScanner(inR)
+	sc.Buffer(nil, 1<<20)
+	b := make(chan *extbot.Bot)
+	go func() { b <- extbot.New(outR, inW, timeout) }()
+	msg := read(t, sc)
+	require.Equal(t, "hello", msg.Type)
+	assert.Equal(t, extbot.Protocol, msg.Protocol)
+	return <-b, sc, outW
+}
+
+func read(t *testing.T, sc *bufio.Scanner) message {
+	t.Helper()
+	require.True(t, sc.Scan())
+	var msg message
+	require.NoError(t, json.Unmarshal(sc.Bytes(), &msg))
+	return msg
+}
+
+// drafting is a snapshot of a player with a pack of two in front of them.
+func drafting() game.StateSnapshotEvent {
+	return game.StateSnapshotEvent{Player: 1, State: game.State{
+		Phase:  game.PhaseDraft,
+		Reveal: []ingredient.Ingredient{{Name: "Rice"}, {Name: "Egg"}},
+	}}
+}
+
+// act runs b.Act in the background, since it blocks on the pipes.
+func act(b *extbot.Bot, e game.Event) chan game.Action {
+	ch := make(chan game.Action, 1)
+	go func() { ch <- b.Act(e) }()
+	return ch
+}
+
+func TestBotAnswersDecisions(t *testing.T) {
+	b, sc, out := fake(t, time.Second)
+	got := act(b, drafting())
+
+	assert.Equal(t, "event", read(t, sc).Type)
+	msg := read(t, sc)
+	require.Equal(t, "decide", msg.Type)
+	assert.Equal(t, 1, msg.Player)
+	assert.Equal(t, []bool{true, true}, msg.Mask.Draft)
+	require.Len(t, msg.Legal, 2)
+
+	fmt.Fprintf(out, "{\"id\":%d,\"index\":1}\n", msg.ID-1)
+	fmt.Fprintf(out, "not json\n")
+	fmt.Fprintf(out, "{\"id\":%d,\"index\":1}\n", msg.ID)
+	assert.Equal(t, game.DraftSelectionAction{Player: 1, Index: 1}, <-got)
+}
+
+func TestBotAcceptsEncodedActions(t *testing.T) {
+	b, sc, out := fake(t, time.Second)
+	got := act(b, drafting())
+	read(t, sc)
+	msg := read(t, sc)
+
+	data, err := game.MarshalAction(game.DraftSelectionAction{Player: 1, Index: 1})
+	require.NoError(t, err)
+	fmt.Fprintf(out, "{\"id\":%d,\"action\":%s}\n", msg.ID, data)
+	assert.Equal(t, game.DraftSelectionAction{Player: 1, Index: 1}, <-got)
+}
+
+func TestBotFallsBackOnTimeout(t *testing.T) {
+	b, sc, _ := fake(t, 20*time.Millisecond)
+	got := act(b, drafting())
+	read(t, sc)
+	read(t, sc)
+	assert.Equal(t, game.DraftSelectionAction{Player: 1, Index: 0}, <-got)
+}
+
+func TestBotFallsBackAfterRejections(t *testing.T) {
+	b, sc, _ := fake(t, time.Second)
+	for range 3 {
+		got := act(b, game.ActionRejectedEvent{Player: 1, Reason: "no"})
+		read(t, sc)
+		assert.Nil(t, <-got)
+	}
+	got := act(b, drafting())
+	assert.Equal(t, "event", read(t, sc).Type)
+	assert.Equal(t, game.DraftSelectionAction{Player: 1, Index: 0}, <-got)
+}
+
+func TestBotIgnoresWaitingSnapshots(t *testing.T) {
+	b, sc, _ := fake(t, time.Second)
+	got := act(b, game.StateSnapshotEvent{State: game.State{Phase: game.PhaseDraft, Waiting: true}})
+	assert.Equal(t, "event", read(t, sc).Type)
+	assert.Nil(t, <-got)
+}
+
+func TestStartPlaysFullTable(t *testing.T) {
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	t.Setenv("EXTBOT_TEST_BOT", "1")
+
+	all := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein},
+		{Name: "Rice", Role: ingredient.Carb},
+		{Name: "Broccoli", Role: ingredient.Vegetable},
+	}
+	g := game.NewMultiplayer(deck.New(all), customer.NewDeck(all), []*player.Player{player.New(), player.New()}, nil, nil)
+
+	var strategies []headless.Strategy
+	for range g.Players {
+		b, err := extbot.Start([]string{exe}, 5*time.Second)
+		require.NoError(t, err)
+		t.Cleanup(func() { b.Close() })
+		strategies = append(strategies, b)
+	}
+	results, err := headless.RunTable(g, strategies)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, res := range results {
+		assert.NotEmpty(t, res.Reason)
+	}
+	for _, s := range strategies {
+		assert.NoError(t, s.(*extbot.Bot).Err())
+	}
+}
+
+func TestStartNeedsCommand(t *testing.T) {
+	_, err := extbot.Start(nil, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,62 @@
+// Package extbot lets an external program play the game over its standard
+// input and output, so bots can be written in any language.
+//
+// Every message is one JSON object per line. The game writes:
+//
+//	{"type":"hello","protocol":1,"timeout_ms":1000}
+//	{"type":"event","event":{...}}
+//	{"type":"decide","id":7,"player":0,"state":{...},"mask":{...},"legal":[{...},...]}
+//
+// hello is sent once. event carries every event the player sees, encoded
+// with the game codec. decide asks for the player's next action: state is a
+// game.State, mask a game.Mask and legal every action the mask allows,
+// encoded with the game codec. The program answers each decide with either
+// an index into legal or an action of its own:
+//
+//	{"id":7,"index":3}
+//	{"id":7,"action":{"version":1,"type":"create_dish","data":{"Player":0,"Name":"Soup","Indices":[0,2]}}}
+//
+// A decision not answered within the timeout, or answered with something
+// that can't be decoded, is settled with the first legal action, which is
+// always a safe default. Answers to earlier decisions are ignored.
+package extbot
+
+import (
+	"encoding/json"
+
+	"executive-chef/internal/game"
+)
+
+// Protocol is the version of the protocol sent in the hello message.
+const Protocol = 1
+
+// hello opens the conversation.
+type hello struct {
+	Type      string `json:"type"`
+	Protocol  int    `json:"protocol"`
+	TimeoutMS int64  `json:"timeout_ms"`
+}
+
+// event forwards a game event.
+type event struct {
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+// decide asks for an action.
+type decide struct {
+	Type   string            `json:"type"`
+	ID     int               `json:"id"`
+	Player int               `json:"player"`
+	State  game.State        `json:"state"`
+	Mask   game.Mask         `json:"mask"`
+	Legal  []json.RawMessage `json:"legal"`
+}
+
+// reply answers a decide message with an index into its legal actions or
+// an encoded action.
+type reply struct {
+	ID     int             `json:"id"`
+	Index  *int            `json:"index,omitempty"`
+	Action json.RawMessage `json:"action,omitempty"`
+}
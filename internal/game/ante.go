@@ -0,0 +1,23 @@
+package game
+
+// TurnsPerAnte is the number of turns grouped into each ante.
+const TurnsPerAnte = 3
+
+// anteTargets holds the money target for the first antes. Each later ante
+// raises the target by anteTargetStep more than the ante before it, so the
+// targets eventually outgrow any restaurant's income.
+var anteTargets = []int{10, 25, 45, 70}
+
+const anteTargetStep = 30
+
+// AnteTarget returns the money the player must have by the end of the given ante.
+func AnteTarget(ante int) int {
+	if ante < 1 {
+		return 0
+	}
+	if ante <= len(anteTargets) {
+		return anteTargets[ante-1]
+	}
+	k := ante - len(anteTargets)
+	return anteTargets[len(anteTargets)-1] + anteTargetStep*k*(k+1)/2
+}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+)
+
+func TestAnteTargetGrows(t *testing.T) {
+	assert.Equal(t, 10, AnteTarget(1))
+	assert.Equal(t, 70, AnteTarget(4))
+	assert.Equal(t, 100, AnteTarget(5))
+	assert.Equal(t, 160, AnteTarget(6))
+	assert.Equal(t, 250, AnteTarget(7))
+}
+
+func TestPlayEndsWhenAnteTargetMissed(t *testing.T) {
+	events := make(chan Event, 100)
+	actions := make(chan Action, TurnsPerAnte)
+	for i := 0; i < TurnsPerAnte; i++ {
+		actions <- FinishDesignAction{}
+	}
+	g := New(&deck.Deck{}, &customer.Deck{}, player.New(), events, actions)
+	g.Play()
+	close(events)
+
+	summary, ok := (<-events).(DeckSummaryEvent)
+	require.True(t, ok, "game should start with a deck summary")
+	assert.Equal(t, 0, summary.Size)
+
+	var end *AnteEndEvent
+	var over *GameOverEvent
+	for e := range events {
+		switch ev := e.(type) {
+		case AnteEndEvent:
+			end = &ev
+		case GameOverEvent:
+			over = &ev
+		}
+	}
+	require.NotNil(t, end)
+	assert.False(t, end.Passed)
+	require.NotNil(t, over)
+	assert.Equal(t, TurnsPerAnte, over.Turn)
+	assert.Equal(t, 1, over.Ante)
+}
+
+func TestBossTurnServesBossWithRule(t *testing.T) {
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	rule := customer.BossRule{Name: "The Critic", RejectRole: ingredient.Carb}
+	boss := customer.Customer{
+		Name:     rule.Name,
+		Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{rice}}},
+		Boss:     &rule,
+	}
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{rice}
+	p.Dishes = []dish.Dish{{Name: "Rice Bowl", Ingredients: []ingredient.Ingredient{rice}}}
+	g := New(nil, &customer.Deck{Bosses: []customer.Customer{boss}}, p, nil, nil)
+	events := playPhase(g, &Turn{Number: 3, Boss: true}, PhaseService, ContinueAction{})
+
+	sr := events[1].(ServiceResultEvent)
+	assert.Equal(t, "The Critic", sr.Customer.Name)
+	assert.Nil(t, sr.Dish)
+	assert.Equal(t, 0, p.Money)
+}
+
+func TestDecksLastBeyondTheirCards(t *testing.T) {
+	for _, players := range []int{1, 3} {
+		g := seededGame(7, players)
+		for _, p := range g.Players {
+			p.Money = 1000
+		}
+		packs := map[int]int{}
+		served := map[int]int{}
+		var bosses []int
+		turn := 0
+		for events := g.Start(); turn <= 6*TurnsPerAnte; {
+			for _, e := range events {
+				switch ev := e.(type) {
+				case PhaseEvent:
+					turn = ev.Turn
+				case AnteStartEvent:
+					if ev.Boss != nil {
+						bosses = append(bosses, ev.Ante)
+					}
+				case DraftOptionsEvent:
+					if len(ev.Reveal) == PackSize {
+						packs[turn]++
+					}
+				case ServiceResultEvent:
+					served[turn]++
+				}
+			}
+			require.False(t, g.Over(), "%d players: game ended on turn %d", players, turn)
+			var err error
+			_, events, err = Apply(g, LegalActions(g)[0])
+			require.NoError(t, err)
+		}
+		for n := 1; n <= 6*TurnsPerAnte; n++ {
+			assert.Equal(t, players, packs[n], "%d players: full packs on turn %d", players, n)
+			assert.GreaterOrEqual(t, served[n], CustomersPerTurn, "%d players: customers on turn %d", players, n)
+		}
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, bosses, "%d players", players)
+	}
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/player"
+	"executive-chef/internal/recipe"
+)
+
+// Clone returns a copy of g that plays on without changing g: the decks,
+// players, recipe discoveries, turn in progress and the game's own random
+// choices are all copied. The rules it plays by, such as the pairings,
+// recipes and payouts, are shared, as are the Events and Actions channels
+// and Rand, which the copy no longer draws from once it has been seeded.
+func (g *Game) Clone() *Game {
+	c := *g
+	if g.Deck != nil {
+		c.Deck = &deck.Deck{Cards: slices.Clone(g.Deck.Cards)}
+	}
+	if g.Customers != nil {
+		c.Customers = &customer.Deck{Cards: slices.Clone(g.Customers.Cards), Bosses: slices.Clone(g.Customers.Bosses)}
+	}
+	c.Players = make([]*player.Player, len(g.Players))
+	for id, p := range g.Players {
+		c.Players[id] = p.Clone()
+	}
+	if g.books != nil {
+		c.books = make([]*recipe.Book, len(g.books))
+		for id, b := range g.books {
+			c.books[id] = b.Clone()
+		}
+	}
+	if g.rnd != nil {
+		c.rnd = rand.New(&c.src)
+	}
+	if g.turn != nil {
+		c.turn = g.turn.clone(&c)
+	}
+	c.pending = slices.Clone(g.pending)
+	return &c
+}
+
+// clone returns a copy of the turn for the game g, a clone of t.Game.
+func (t *Turn) clone(g *Game) *Turn {
+	c := *t
+	c.Game = g
+	c.doublePayment = maps.Clone(t.doublePayment)
+	c.packs = cloneAll(t.packs)
+	c.customers = slices.Clone(t.customers)
+	c.menus = cloneAll(t.menus)
+	c.offers = maps.Clone(t.offers)
+	c.created = cloneAll(t.created)
+	c.finished = slices.Clone(t.finished)
+	c.peeked = maps.Clone(t.peeked)
+	if t.result != nil {
+		result := *t.result
+		c.result = &result
+	}
+	c.continued = maps.Clone(t.continued)
+	c.stale = maps.Clone(t.stale)
+	return &c
+}
+
+// cloneAll copies each of the slices in s.
+func cloneAll[T any](s [][]T) [][]T {
+	if s == nil {
+		return nil
+	}
+	out := make([][]T, len(s))
+	for i, v := range s {
+		out[i] = slices.Clone(v)
+	}
+	return out
+}
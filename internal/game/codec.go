@@ -0,0 +1,199 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// CodecVersion is the version of the JSON encoding written by MarshalEvent
+// and MarshalAction. Decoding accepts any version up to it.
+const CodecVersion = 1
+
+// envelope is the JSON form of an event or action. Type holds its
+// EventType or ActionType and Data its fields.
+type envelope struct {
+	Version int             `json:"version"`
+	Type    string          `json:"type"`
+	Data    json.RawMessage `json:"data"`
+}
+
+var (
+	eventTypes  = make(map[string]reflect.Type)
+	actionTypes = make(map[string]reflect.Type)
+)
+
+func init() {
+	for _, e := range []Event{
+		DeckSummaryEvent{},
+		PhaseEvent{},
+		DraftOptionsEvent{},
+		IngredientDraftedEvent{},
+		DesignOptionsEvent{},
+		DishCreatedEvent{},
+		DishDeletedEvent{},
+		RecipeBookEvent{},
+		RecipeDiscoveredEvent{},
+		ServiceResultEvent{},
+		ServiceEndEvent{},
+		ConsumableGainedEvent{},
+		ConsumableUsedEvent{},
+		CustomersPeekedEvent{},
+		IngredientTransmutedEvent{},
+		AnteStartEvent{},
+		AnteEndEvent{},
+		GameOverEvent{},
+		StateSnapshotEvent{},
+		ActionRejectedEvent{},
+	} {
+		RegisterEvent(e)
+	}
+	for _, a := range []Action{
+		DraftSelectionAction{},
+		CreateDishAction{},
+		DeleteDishAction{},
+		FinishDesignAction{},
+		ContinueAction{},
+		UseConsumableAction{},
+		RequestSnapshotAction{},
+	} {
+		RegisterAction(a)
+	}
+}
+
+// RegisterEvent makes the concrete type of e known to the codec under its
+// EventType. It panics if another type is registered under the same name.
+func RegisterEvent(e Event) {
+	register(eventTypes, e.EventType(), e)
+}
+
+// RegisterAction makes the concrete type of a known to the codec under its
+// ActionType. It panics if another type is registered under the same name.
+func RegisterAction(a Action) {
+	register(actionTypes, a.ActionType(), a)
+}
+
+func register(types map[string]reflect.Type, name string, v any) {
+	if _, dup := types[name]; dup {
+		panic(fmt.Sprintf("game: type %q registered twice", name))
+	}
+	types[name] = reflect.TypeOf(v)
+}
+
+// EventTypes lists the registered event types in alphabetical order.
+func EventTypes() []string {
+	return typeNames(eventTypes)
+}
+
+// ActionTypes lists the registered action types in alphabetical order.
+func ActionTypes() []string {
+	return typeNames(actionTypes)
+}
+
+func typeNames(types map[string]reflect.Type) []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MarshalEvent encodes e as JSON tagged with its type and the codec version.
+func MarshalEvent(e Event) ([]byte, error) {
+	return marshal(eventTypes, e.EventType(), e)
+}
+
+// UnmarshalEvent decodes an event encoded by MarshalEvent.
+func UnmarshalEvent(data []byte) (Event, error) {
+	v, err := unmarshal(eventTypes, "event", data)
+	if err != nil {
+		return nil, err
+	}
+	return v.(Event), nil
+}
+
+// MarshalAction encodes a as JSON tagged with its type and the codec version.
+func MarshalAction(a Action) ([]byte, error) {
+	return marshal(actionTypes, a.ActionType(), a)
+}
+
+// UnmarshalAction decodes an action encoded by MarshalAction.
+func UnmarshalAction(data []byte) (Action, error) {
+	v, err := unmarshal(actionTypes, "action", data)
+	if err != nil {
+		return nil, err
+	}
+	return v.(Action), nil
+}
+
+func marshal(types map[string]reflect.Type, name string, v any) ([]byte, error) {
+	if types[name] != reflect.TypeOf(v) {
+		return nil, fmt.Errorf("game: %T is not registered as %q", v, name)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope{Version: CodecVersion, Type: name, Data: data})
+}
+
+func unmarshal(types map[string]reflect.Type, kind string, data []byte) (any, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("game: decoding %s: %w", kind, err)
+	}
+	if env.Version < 1 || env.Version > CodecVersion {
+		return nil, fmt.Errorf("game: unsupported %s version %d", kind, env.Version)
+	}
+	t, ok := types[env.Type]
+	if !ok {
+		return nil, fmt.Errorf("game: unknown %s type %q", kind, env.Type)
+	}
+	v := reflect.New(t)
+	if len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, v.Interface()); err != nil {
+			return nil, fmt.Errorf("game: decoding %s %q: %w", kind, env.Type, err)
+		}
+	}
+	return v.Elem().Interface(), nil
+}
+
+// rejectedJSON is the JSON form of an ActionRejectedEvent, whose Action is
+// encoded with its own type.
+type rejectedJSON struct {
+	Player int
+	Action json.RawMessage
+	Reason string
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ActionRejectedEvent) MarshalJSON() ([]byte, error) {
+	out := rejectedJSON{Player: e.Player, Reason: e.Reason, Action: json.RawMessage("null")}
+	if e.Action != nil {
+		a, err := MarshalAction(e.Action)
+		if err != nil {
+			return nil, err
+		}
+		out.Action = a
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ActionRejectedEvent) UnmarshalJSON(data []byte) error {
+	var in rejectedJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*e = ActionRejectedEvent{Player: in.Player, Reason: in.Reason}
+	if len(in.Action) > 0 && string(in.Action) != "null" {
+		a, err := UnmarshalAction(in.Action)
+		if err != nil {
+			return err
+		}
+		e.Action = a
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/consumable"
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/pairing"
+	"executive-chef/internal/recipe"
+)
+
+func codecSamples() ([]Event, []Action) {
+	miso := ingredient.Ingredient{Name: "Miso", Role: ingredient.Sauce, Cost: 2, Rarity: ingredient.Rare, Cuisine: "Japanese", Flavor: ingredient.Flavor{Salty: 2, Umami: 3}}
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	soup := dish.Dish{Name: "Miso Soup", Ingredients: []ingredient.Ingredient{miso, tofu}}
+	boss := customer.Bosses[0]
+	patron := customer.Customer{Name: "Patron", Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{miso}}}, Constraint: &tofu, Boss: &boss}
+	entry := recipe.Entry{Name: "Miso Soup", Ingredients: []string{"Miso", "Tofu"}, Size: 2, Bonus: 3, Discovered: true}
+	double := consumable.Consumable{Kind: consumable.Double}
+
+	actions := []Action{
+		DraftSelectionAction{Player: 1, Index: 2},
+		CreateDishAction{Player: 1, Name: "Soup", Indices: []int{0, 1}},
+		DeleteDishAction{Index: 3},
+		FinishDesignAction{Player: 2},
+		ContinueAction{},
+		UseConsumableAction{Index: 1, Target: 4},
+		RequestSnapshotAction{Player: 3},
+	}
+	events := []Event{
+		DeckSummaryEvent{Size: 2, Composition: []deck.Entry{{Ingredient: miso, Count: 2}}},
+		PhaseEvent{Turn: 3, Phase: PhaseDesign},
+		DraftOptionsEvent{Player: 1, Reveal: []ingredient.Ingredient{miso, tofu}, Picks: 4},
+		IngredientDraftedEvent{Ingredient: tofu},
+		DesignOptionsEvent{Drafted: []ingredient.Ingredient{miso}, Pairings: &pairing.Table{Pairings: []pairing.Pairing{{Ingredients: [2]string{"Miso", "Tofu"}, Affinity: pairing.Great}}}},
+		DishCreatedEvent{Player: 2, Dish: soup},
+		DishDeletedEvent{Dish: soup, Index: 1},
+		RecipeBookEvent{Player: 1, Entries: []recipe.Entry{entry, {Size: 3, Bonus: 1}}},
+		RecipeDiscoveredEvent{Player: 1, Index: 0, Entry: entry},
+		ServiceResultEvent{Player: 1, Customer: patron, Dish: &soup, Payment: 9, Money: 20, Craving: 0, Satisfaction: Full, Extras: 1, Penalty: 1, Synergy: 3, Archetype: dish.Bowl, Recipe: "Miso Soup", Doubled: true},
+		ServiceResultEvent{Player: -1, Customer: patron, Craving: -1, Satisfaction: Mismatch},
+		ServiceEndEvent{},
+		ConsumableGainedEvent{Consumable: double},
+		ConsumableUsedEvent{Consumable: double, Index: 2},
+		CustomersPeekedEvent{Customers: []customer.Customer{patron}},
+		IngredientTransmutedEvent{Index: 1, From: tofu, To: miso},
+		AnteStartEvent{Ante: 2, Target: 25, Turns: 3, Boss: &boss},
+		AnteEndEvent{Ante: 2, Target: 25, Money: 30, Passed: true},
+		GameOverEvent{Player: 1, Turn: 6, Ante: 2, Money: 12, Reason: "missed"},
+		StateSnapshotEvent{Player: 1, State: State{
+			Turn: 3, Phase: PhaseService, Ante: 1, AnteTurns: 3, Target: 10, Boss: &boss, Money: 7,
+			Drafted: []ingredient.Ingredient{miso, tofu}, Menu: []MenuItem{{Dish: soup, Have: 2, New: true}},
+			Consumables: []consumable.Consumable{double}, Peeked: []customer.Customer{patron}, Recipes: []recipe.Entry{entry},
+			Reveal: []ingredient.Ingredient{tofu}, Picks: 2, DishesLeft: 1, Waiting: true, DoubleNext: true,
+			Result: &ServiceResultEvent{Player: 1, Customer: patron, Dish: &soup, Payment: 9, Money: 7},
+		}},
+
+		ActionRejectedEvent{Player: 1, Action: actions[1], Reason: "no"},
+		ActionRejectedEvent{Reason: "no action"},
+	}
+	return events, actions
+}
+
+func TestCodecRoundTripsEveryEvent(t *testing.T) {
+	events, _ := codecSamples()
+	covered := make(map[string]bool)
+	for _, e := range events {
+		data, err := MarshalEvent(e)
+		require.NoError(t, err, e.EventType())
+		got, err := UnmarshalEvent(data)
+		require.NoError(t, err, e.EventType())
+		assert.Equal(t, e, got)
+		covered[e.EventType()] = true
+	}
+	for _, name := range EventTypes() {
+		assert.True(t, covered[name], "no sample for %s", name)
+	}
+}
+
+func TestCodecRoundTripsEveryAction(t *testing.T) {
+	_, actions := codecSamples()
+	covered := make(map[string]bool)
+	for _, a := range actions {
+		data, err := MarshalAction(a)
+		require.NoError(t, err, a.ActionType())
+		got, err := UnmarshalAction(data)
+		require.NoError(t, err, a.ActionType())
+		assert.Equal(t, a, got)
+		covered[a.ActionType()] = true
+	}
+	for _, name := range ActionTypes() {
+		assert.True(t, covered[name], "no sample for %s", name)
+	}
+}
+
+func TestCodecEnvelope(t *testing.T) {
+	data, err := MarshalAction(DraftSelectionAction{Player: 1, Index: 2})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":1,"type":"draft_selection","data":{"Player":1,"Index":2}}`, string(data))
+
+	_, err = UnmarshalAction([]byte(`{"version":2,"type":"continue","data":{}}`))
+	assert.EqualError(t, err, "game: unsupported action version 2")
+	_, err = UnmarshalEvent([]byte(`{"version":1,"type":"teleport","data":{}}`))
+	assert.EqualError(t, err, `game: unknown event type "teleport"`)
+	_, err = UnmarshalEvent([]byte(`{"version":1,"type":"continue","data":{}}`))
+	assert.EqualError(t, err, `game: unknown event type "continue"`)
+}
+
+type unregisteredAction struct{}
+
+func (unregisteredAction) ActionType() string { return "continue" }
+func (unregisteredAction) PlayerID() int      { return 0 }
+
+func TestCodecRejectsUnregisteredTypes(t *testing.T) {
+	_, err := MarshalAction(unregisteredAction{})
+	assert.EqualError(t, err, `game: game.unregisteredAction is not registered as "continue"`)
+	assert.Panics(t, func() { RegisterAction(unregisteredAction{}) })
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"executive-chef/internal/consumable"
+	"executive-chef/internal/ingredient"
+)
+
+// consumablePhases lists the phases in which each consumable may be played.
+var consumablePhases = map[consumable.Kind][]Phase{
+	consumable.Reroll:    {PhaseDraft},
+	consumable.Peek:      {PhaseDraft, PhaseDesign},
+	consumable.Double:    {PhaseDesign, PhaseService},
+	consumable.Transmute: {PhaseDesign},
+}
+
+// UsableIn reports whether a consumable of the given kind may be played during phase.
+func UsableIn(kind consumable.Kind, phase Phase) bool {
+	for _, p := range consumablePhases[kind] {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// dealConsumable gives the player with the given ID a random consumable if
+// their hand has room.
+func (t *Turn) dealConsumable(id int) {
+	c := consumable.RandomFrom(t.Game.random())
+	if t.Game.Players[id].AddConsumable(c) {
+		t.emit(ConsumableGainedEvent{Player: id, Consumable: c})
+	}
+}
+
+// useConsumable validates and applies a consumable played during phase.
+// reveal points at the acting player's current draft pack and is nil outside
+// the draft phase. It returns an error explaining why the consumable could
+// not be played.
+func (t *Turn) useConsumable(phase Phase, a UseConsumableAction, reveal *[]ingredient.Ingredient) error {
+	if a.Player < 0 || a.Player >= len(t.Game.Players) {
+		return fmt.Errorf("no player %d", a.Player)
+	}
+	p := t.Game.Players[a.Player]
+	if a.Index < 0 || a.Index >= len(p.Consumables) {
+		return fmt.Errorf("no consumable at index %d", a.Index)
+	}
+	c := p.Consumables[a.Index]
+	if !UsableIn(c.Kind, phase) {
+		return fmt.Errorf("%s can't be used during the %s phase", c.Kind, phase)
+	}
+	var transmuted ingredient.Ingredient
+	switch c.Kind {
+	case consumable.Reroll:
+		if reveal != nil {
+			t.Game.restockIngredients(len(*reveal))
+		}
+		if reveal == nil || t.Game.Deck == nil || len(t.Game.Deck.Cards) == 0 {
+			return errors.New("no ingredients left to reveal")
+		}
+	case consumable.Peek:
+		if t.Game.Customers == nil {
+			return errors.New("no customers to peek at")
+		}
+	case consumable.Transmute:
+		if a.Target < 0 || a.Target >= len(p.Drafted) {
+			return fmt.Errorf("no drafted ingredient at index %d", a.Target)
+		}
+		var pool []ingredient.Ingredient
+		if t.Game.Deck != nil {
+			pool = t.Game.Deck.Cards
+		}
+		candidates := transmuteCandidates(p.Drafted[a.Target], pool)
+		if len(candidates) == 0 {
+			return fmt.Errorf("nothing to transmute %s into", p.Drafted[a.Target].Name)
+		}
+		transmuted = candidates[t.Game.random().Intn(len(candidates))]
+	}
+
+	p.RemoveConsumable(a.Index)
+	t.emit(ConsumableUsedEvent{Player: a.Player, Consumable: c, Index: a.Index})
+	switch c.Kind {
+	case consumable.Reroll:
+		fresh := t.Game.Deck.Draw(len(*reveal))
+		sortReveal(fresh)
+		*reveal = fresh
+	case consumable.Peek:
+		t.Game.restockCustomers()
+		peeked := t.Game.Customers.Peek(CustomersPerTurn)
+		t.peek(a.Player, peeked)
+		t.emit(CustomersPeekedEvent{Player: a.Player, Customers: peeked})
+	case consumable.Double:
+		if t.doublePayment == nil {
+			t.doublePayment = make(map[int]bool)
+		}
+		t.doublePayment[a.Player] = true
+	case consumable.Transmute:
+		from := p.Drafted[a.Target]
+		p.ReplaceDrafted(a.Target, transmuted)
+		t.emit(IngredientTransmutedEvent{Player: a.Player, Index: a.Target, From: from, To: transmuted})
+	}
+	return nil
+}
+
+// transmuteCandidates returns the distinct ingredients in pool that share the
+// role of ing but not its name.
+func transmuteCandidates(ing ingredient.Ingredient, pool []ingredient.Ingredient) []ingredient.Ingredient {
+	var candidates []ingredient.Ingredient
+	seen := make(map[ingredient.Ingredient]bool)
+	for _, c := range pool {
+		if c.Role != ing.Role || c.Name == ing.Name || seen[c] {
+			continue
+		}
+		seen[c] = true
+		candidates = append(candidates, c)
+	}
+	return candidates
+}
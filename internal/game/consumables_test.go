@@ -0,0 +1,108 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/consumable"
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+)
+
+func TestRerollReplacesDraftReveal(t *testing.T) {
+	var cards []ingredient.Ingredient
+	for i := 0; i < 10; i++ {
+		cards = append(cards, ingredient.Ingredient{Name: "Old", Role: ingredient.Protein})
+	}
+	for i := 0; i < 10; i++ {
+		cards = append(cards, ingredient.Ingredient{Name: "New", Role: ingredient.Carb})
+	}
+	p := player.New()
+	p.Consumables = []consumable.Consumable{{Kind: consumable.Reroll}, {Kind: consumable.Peek}, {Kind: consumable.Peek}}
+	g := New(&deck.Deck{Cards: cards}, nil, p, nil, nil)
+	playPhase(g, &Turn{Number: 1}, PhaseDraft,
+		UseConsumableAction{Index: 0},
+		DraftSelectionAction{Index: 0},
+		DraftSelectionAction{Index: 0},
+		DraftSelectionAction{Index: 0},
+	)
+
+	require.Len(t, p.Drafted, 3)
+	for _, ing := range p.Drafted {
+		assert.Equal(t, "New", ing.Name)
+	}
+	assert.Len(t, p.Consumables, 2)
+}
+
+func TestRerollRestocksEmptyDeck(t *testing.T) {
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	p := player.New()
+	p.Consumables = []consumable.Consumable{{Kind: consumable.Reroll}}
+	g := New(&deck.Deck{Cards: []ingredient.Ingredient{rice, rice}}, nil, p, nil, nil)
+	g.takeStock()
+	g.Deck.Cards = nil
+	turn := Turn{Number: 1, Game: g}
+
+	reveal := []ingredient.Ingredient{{Name: "Tofu", Role: ingredient.Protein}}
+	require.NoError(t, turn.useConsumable(PhaseDraft, UseConsumableAction{Index: 0}, &reveal))
+	assert.Equal(t, []ingredient.Ingredient{rice}, reveal)
+}
+
+func TestConsumableRejectedOutsideItsPhase(t *testing.T) {
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{{Name: "Chicken", Role: ingredient.Protein}}
+	p.Consumables = []consumable.Consumable{{Kind: consumable.Reroll}}
+	g := New(&deck.Deck{}, nil, p, make(chan Event, 6), nil)
+	turn := Turn{Number: 1, Game: g}
+
+	assert.EqualError(t, turn.useConsumable(PhaseDesign, UseConsumableAction{Index: 0}, nil), "Reroll can't be used during the Design phase")
+	assert.Len(t, p.Consumables, 1)
+}
+
+func TestTransmuteReplacesIngredientWithSameRole(t *testing.T) {
+	chicken := ingredient.Ingredient{Name: "Chicken", Role: ingredient.Protein}
+	beef := ingredient.Ingredient{Name: "Beef", Role: ingredient.Protein}
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{chicken}
+	p.Consumables = []consumable.Consumable{{Kind: consumable.Transmute}}
+	g := New(&deck.Deck{Cards: []ingredient.Ingredient{rice, chicken, beef}}, nil, p, nil, nil)
+	turn := Turn{Number: 1, Game: g}
+
+	require.NoError(t, turn.useConsumable(PhaseDesign, UseConsumableAction{Index: 0, Target: 0}, nil))
+	assert.Equal(t, []ingredient.Ingredient{beef}, p.Drafted)
+	assert.Empty(t, p.Consumables)
+	require.Len(t, g.pending, 2) // consumable used, then transmuted
+	ev := g.pending[1].(IngredientTransmutedEvent)
+	assert.Equal(t, chicken, ev.From)
+	assert.Equal(t, beef, ev.To)
+}
+
+func TestDoubleConsumableDoublesNextPayment(t *testing.T) {
+	ing := ingredient.Ingredient{Name: "Chicken", Role: ingredient.Protein}
+	cust := customer.Customer{
+		Name:     "Patron",
+		Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{ing}}},
+	}
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{ing}
+	p.Dishes = []dish.Dish{{Name: "Dish", Ingredients: []ingredient.Ingredient{ing}}}
+	p.Consumables = []consumable.Consumable{{Kind: consumable.Double}}
+	g := New(nil, &customer.Deck{Cards: []customer.Customer{cust}}, p, nil, nil)
+	turn := &Turn{Number: 1, Game: g}
+
+	require.NoError(t, turn.useConsumable(PhaseDesign, UseConsumableAction{Index: 0}, nil))
+	events := playPhase(g, turn, PhaseService, ContinueAction{})
+
+	assert.IsType(t, ConsumableUsedEvent{}, events[0])
+	assert.IsType(t, PhaseEvent{}, events[1])
+	sr := events[2].(ServiceResultEvent)
+	assert.True(t, sr.Doubled)
+	assert.Equal(t, 10, sr.Payment)
+	assert.Equal(t, 10, p.Money)
+}
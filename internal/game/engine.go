@@ -0,0 +1,176 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotStarted is returned by Apply for a game that was not started.
+	ErrNotStarted = errors.New("game not started")
+	// ErrGameOver is returned by Apply once the game is over.
+	ErrGameOver = errors.New("game is over")
+	// ErrRejected wraps the reason Apply ignored an action.
+	ErrRejected = errors.New("action rejected")
+)
+
+// Start begins the game and returns the events up to the first decision,
+// ending with a snapshot for every player. The game then advances one action
+// at a time with Apply, without goroutines or channels.
+func (g *Game) Start() []Event {
+	g.pending = append(g.pending, DeckSummaryEvent{Size: len(g.Deck.Cards), Composition: g.Deck.Composition()})
+	g.books = nil
+	g.rnd = nil
+	if g.Recipes != nil {
+		for id := range g.Players {
+			g.pending = append(g.pending, RecipeBookEvent{Player: id, Entries: g.book(id).Entries()})
+		}
+	}
+	g.Ante = 0
+	g.over = false
+	g.takeStock()
+	g.startAnte(1)
+	return g.wait()
+}
+
+// Apply plays action a in g and returns the game together with the events
+// that followed, up to the next decision. Once the game waits for actions
+// again, the events end with a snapshot for every player whose state
+// changed.
+//
+// Apply changes g in place and returns g itself, which keeps long
+// simulations cheap. engine.Apply plays on a Clone instead, leaving the
+// state it was given untouched.
+//
+// An action the rules don't allow is answered with an ActionRejectedEvent
+// and a snapshot for its player, and Apply returns them with an error
+// wrapping ErrRejected. A RequestSnapshotAction returns just the snapshot.
+func Apply(g *Game, a Action) (*Game, []Event, error) {
+	if g.turn == nil {
+		return g, nil, ErrNotStarted
+	}
+	if g.over {
+		return g, nil, ErrGameOver
+	}
+	g.rejection = ""
+	g.turn.apply(a)
+	events := g.wait()
+	if g.rejection != "" {
+		return g, events, fmt.Errorf("%w: %s", ErrRejected, g.rejection)
+	}
+	return g, events, nil
+}
+
+// LegalActions lists every action the rules allow next, for every player
+// with a decision to make, stamped with their player IDs. Each player's
+// actions follow State.LegalActions, so a player's first one is always a
+// safe default. It returns nil for a game that was not started or is over.
+func LegalActions(g *Game) []Action {
+	if g.turn == nil || g.over {
+		return nil
+	}
+	var out []Action
+	for id := range g.Players {
+		for _, a := range g.turn.snapshot(id).LegalActions() {
+			out = append(out, ForPlayer(a, id))
+		}
+	}
+	return out
+}
+
+// Over reports whether the game is over.
+func (g *Game) Over() bool {
+	return g.over
+}
+
+// wait moves past completed phases until the game needs actions or is over,
+// and returns the events since the last call.
+func (g *Game) wait() []Event {
+	for !g.over && g.turn.complete {
+		switch t := g.turn; t.phase {
+		case PhaseDraft:
+			t.startDesign()
+		case PhaseDesign:
+			t.startService()
+		default:
+			g.endTurn()
+		}
+	}
+	if !g.over {
+		g.turn.settle()
+	}
+	events := g.pending
+	g.pending = nil
+	return events
+}
+
+// startAnte announces the next ante and starts its first turn, numbered
+// turn.
+func (g *Game) startAnte(turn int) {
+	g.Ante++
+	g.boss = nil
+	g.restockCustomers()
+	if b, ok := g.Customers.PeekBoss(); ok {
+		g.boss = b.Boss
+	}
+	g.pending = append(g.pending, AnteStartEvent{Ante: g.Ante, Target: AnteTarget(g.Ante), Turns: TurnsPerAnte, Boss: g.boss})
+	g.startTurn(turn)
+}
+
+// startTurn starts the turn with the given number. The last turn of each
+// ante is a boss turn.
+func (g *Game) startTurn(number int) {
+	g.turn = &Turn{Number: number, Game: g, Boss: number%TurnsPerAnte == 0}
+	g.turn.startDraft()
+}
+
+// endTurn clears the players' drafted ingredients and starts the next turn.
+// After a boss turn it checks every player against the ante's target, and
+// the game is over if anyone fell short.
+func (g *Game) endTurn() {
+	t := g.turn
+	for _, p := range g.Players {
+		p.ResetTurn()
+	}
+	if !t.Boss {
+		g.startTurn(t.Number + 1)
+		return
+	}
+	target := AnteTarget(g.Ante)
+	var missed []int
+	for id, p := range g.Players {
+		passed := p.Money >= target
+		g.pending = append(g.pending, AnteEndEvent{Player: id, Ante: g.Ante, Target: target, Money: p.Money, Passed: passed})
+		if !passed {
+			missed = append(missed, id)
+		}
+	}
+	if len(missed) > 0 {
+		g.gameOver(t.Number, target, missed)
+		return
+	}
+	g.startAnte(t.Number + 1)
+}
+
+// apply plays an action in the turn's current phase. Snapshots requested
+// with a RequestSnapshotAction are answered right away.
+func (t *Turn) apply(act Action) {
+	if _, ok := act.(RequestSnapshotAction); ok {
+		if id := act.PlayerID(); id >= 0 && id < len(t.Game.Players) {
+			t.Game.pending = append(t.Game.pending, t.snapshot(id))
+			return
+		}
+	}
+	p, ok := t.player(act)
+	if !ok {
+		return
+	}
+	switch t.phase {
+	case PhaseDraft:
+		t.draftAction(p, act)
+	case PhaseDesign:
+		t.designAction(p, act)
+	case PhaseService:
+		t.serviceAction(act)
+	}
+}
@@ -0,0 +1,172 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+	"executive-chef/internal/rng"
+)
+
+// seededGame builds a game whose every random choice comes from seed.
+func seededGame(seed int64, players int) *Game {
+	all := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein},
+		{Name: "Tofu", Role: ingredient.Protein},
+		{Name: "Rice", Role: ingredient.Carb},
+		{Name: "Broccoli", Role: ingredient.Vegetable},
+	}
+	r := rng.New(seed)
+	d, _ := deck.BuildFrom(r, all, deck.Recipe{Size: deck.DefaultSize})
+	seats := make([]*player.Player, players)
+	for i := range seats {
+		seats[i] = player.New()
+	}
+	g := NewMultiplayer(d, customer.NewDeckFrom(r, all), seats, nil, nil)
+	g.Rand = r
+	return g
+}
+
+// playPhase makes turn g's current turn, starts phase and plays actions
+// with Apply until the phase is over, returning the events up to the start
+// of the next phase. The game carries on past the phase as it would in
+// play, with empty decks standing in for missing ones.
+func playPhase(g *Game, turn *Turn, phase Phase, actions ...Action) []Event {
+	if g.Deck == nil {
+		g.Deck = &deck.Deck{}
+	}
+	if g.Customers == nil {
+		g.Customers = &customer.Deck{}
+	}
+	turn.Game = g
+	g.turn = turn
+	switch phase {
+	case PhaseDraft:
+		turn.startDraft()
+	case PhaseDesign:
+		turn.startDesign()
+	default:
+		turn.startService()
+	}
+	events := g.wait()
+	for _, a := range actions {
+		if g.over || g.turn != turn || turn.phase != phase {
+			break
+		}
+		_, more, _ := Apply(g, a)
+		events = append(events, more...)
+	}
+	started := false
+	for i, e := range events {
+		if _, ok := e.(PhaseEvent); ok {
+			if started {
+				return events[:i]
+			}
+			started = true
+		}
+	}
+	return events
+}
+
+func TestStartRunsToFirstDecision(t *testing.T) {
+	g := seededGame(1, 2)
+	events := g.Start()
+	require.NotEmpty(t, events)
+	assert.IsType(t, DeckSummaryEvent{}, events[0])
+	assert.Equal(t, 1, g.Ante)
+
+	var snaps []StateSnapshotEvent
+	for _, e := range events {
+		if s, ok := e.(StateSnapshotEvent); ok {
+			snaps = append(snaps, s)
+		}
+	}
+	require.Len(t, snaps, 2)
+	assert.Equal(t, PhaseDraft, snaps[0].Phase)
+	assert.False(t, snaps[0].Waiting)
+
+	legal := LegalActions(g)
+	players := map[int]bool{}
+	for _, a := range legal {
+		players[a.PlayerID()] = true
+	}
+	assert.Equal(t, map[int]bool{0: true, 1: true}, players)
+}
+
+func TestApplyReportsRejections(t *testing.T) {
+	g := New(nil, nil, player.New(), nil, nil)
+	_, _, err := Apply(g, ContinueAction{})
+	assert.ErrorIs(t, err, ErrNotStarted)
+
+	g = seededGame(1, 1)
+	g.Start()
+	_, events, err := Apply(g, FinishDesignAction{})
+	assert.ErrorIs(t, err, ErrRejected)
+	assert.EqualError(t, err, "action rejected: not allowed during the draft phase")
+	require.Len(t, events, 2)
+	assert.IsType(t, ActionRejectedEvent{}, events[0])
+	assert.IsType(t, StateSnapshotEvent{}, events[1])
+
+	_, events, err = Apply(g, RequestSnapshotAction{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, PhaseDraft, events[0].(StateSnapshotEvent).Phase)
+}
+
+func TestApplyPlaysGameToTheEnd(t *testing.T) {
+	g := seededGame(3, 1)
+	g.Start()
+	var last []Event
+	for steps := 0; !g.Over(); steps++ {
+		require.Less(t, steps, 10000, "game never ended")
+		legal := LegalActions(g)
+		require.NotEmpty(t, legal)
+		var err error
+		_, last, err = Apply(g, legal[0])
+		require.NoError(t, err)
+	}
+	assert.Nil(t, LegalActions(g))
+	assert.IsType(t, GameOverEvent{}, last[len(last)-1])
+	_, _, err := Apply(g, ContinueAction{})
+	assert.ErrorIs(t, err, ErrGameOver)
+}
+
+func TestPlayMatchesApply(t *testing.T) {
+	// Step one game, noting which action followed which event.
+	type step struct {
+		after  int
+		action Action
+	}
+	stepped := seededGame(5, 2)
+	want := stepped.Start()
+	var steps []step
+	for !stepped.Over() {
+		a := LegalActions(stepped)[0]
+		steps = append(steps, step{after: len(want), action: a})
+		_, events, err := Apply(stepped, a)
+		require.NoError(t, err)
+		want = append(want, events...)
+	}
+
+	// Play the same game over channels, sending the same actions.
+	played := seededGame(5, 2)
+	events := make(chan Event)
+	actions := make(chan Action, len(steps))
+	played.Events = events
+	played.Actions = actions
+	go played.Play()
+	var got []Event
+	for len(got) < len(want) {
+		for len(steps) > 0 && steps[0].after == len(got) {
+			actions <- steps[0].action
+			steps = steps[1:]
+		}
+		got = append(got, <-events)
+	}
+	assert.Equal(t, want, got)
+}
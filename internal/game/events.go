@@ -1,9 +1,13 @@
 package game
 
 import (
+	"executive-chef/internal/consumable"
 	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
 	"executive-chef/internal/dish"
 	"executive-chef/internal/ingredient"
+	"executive-chef/internal/pairing"
+	"executive-chef/internal/recipe"
 )
 
 // Event represents something that happened in the game and should be rendered by the UI.
@@ -11,6 +15,20 @@ type Event interface {
 	EventType() string
 }
 
+// PlayerEvent is implemented by events addressed to a single player, whose
+// ID is returned by PlayerID. Events that don't implement it are broadcast to
+// every player.
+type PlayerEvent interface {
+	Event
+	PlayerID() int
+}
+
+// VisibleTo reports whether the player with the given ID should receive e.
+func VisibleTo(e Event, id int) bool {
+	pe, ok := e.(PlayerEvent)
+	return !ok || pe.PlayerID() == id
+}
+
 // Phase represents the current phase of a turn.
 type Phase string
 
@@ -20,6 +38,14 @@ const (
 	PhaseService Phase = "Service"
 )
 
+// DeckSummaryEvent lists the composition of the ingredient deck at the start of the game.
+type DeckSummaryEvent struct {
+	Size        int
+	Composition []deck.Entry
+}
+
+func (e DeckSummaryEvent) EventType() string { return "deck_summary" }
+
 // PhaseEvent announces the current turn and phase of the game.
 type PhaseEvent struct {
 	Turn  int
@@ -30,49 +56,101 @@ func (e PhaseEvent) EventType() string { return "phase" }
 
 // DraftOptionsEvent is sent when a new set of draftable ingredients should be shown.
 type DraftOptionsEvent struct {
+	Player int
 	Reveal []ingredient.Ingredient
 	Picks  int
 }
 
 func (e DraftOptionsEvent) EventType() string { return "draft_options" }
+func (e DraftOptionsEvent) PlayerID() int     { return e.Player }
 
 // IngredientDraftedEvent announces that an ingredient has been drafted by the player.
 type IngredientDraftedEvent struct {
+	Player     int
 	Ingredient ingredient.Ingredient
 }
 
 func (e IngredientDraftedEvent) EventType() string { return "ingredient_drafted" }
+func (e IngredientDraftedEvent) PlayerID() int     { return e.Player }
 
 // DesignOptionsEvent is sent when the player can design dishes from drafted ingredients.
+// Pairings lets the UI preview the synergy of a dish while it is designed.
 type DesignOptionsEvent struct {
-	Drafted []ingredient.Ingredient
+	Player   int
+	Drafted  []ingredient.Ingredient
+	Pairings *pairing.Table
 }
 
 func (e DesignOptionsEvent) EventType() string { return "design_options" }
+func (e DesignOptionsEvent) PlayerID() int     { return e.Player }
 
 // DishCreatedEvent notifies the UI that a dish has been created.
 type DishCreatedEvent struct {
-	Dish dish.Dish
+	Player int
+	Dish   dish.Dish
 }
 
 func (e DishCreatedEvent) EventType() string { return "dish_created" }
+func (e DishCreatedEvent) PlayerID() int     { return e.Player }
 
 // DishDeletedEvent notifies the UI that a dish has been deleted.
 // Index refers to the position of the dish in the player's dish list.
 type DishDeletedEvent struct {
-	Dish  dish.Dish
-	Index int
+	Player int
+	Dish   dish.Dish
+	Index  int
 }
 
 func (e DishDeletedEvent) EventType() string { return "dish_deleted" }
+func (e DishDeletedEvent) PlayerID() int     { return e.Player }
+
+// RecipeBookEvent lists every recipe in the player's book, with the recipes
+// they haven't discovered hidden.
+type RecipeBookEvent struct {
+	Player  int
+	Entries []recipe.Entry
+}
+
+func (e RecipeBookEvent) EventType() string { return "recipe_book" }
+func (e RecipeBookEvent) PlayerID() int     { return e.Player }
+
+// RecipeDiscoveredEvent reveals the recipe at Index in the player's recipe
+// book after they designed a dish matching it for the first time.
+type RecipeDiscoveredEvent struct {
+	Player int
+	Index  int
+	Entry  recipe.Entry
+}
+
+func (e RecipeDiscoveredEvent) EventType() string { return "recipe_discovered" }
+func (e RecipeDiscoveredEvent) PlayerID() int     { return e.Player }
 
-// ServiceResultEvent reports which dish a customer selected.
-// Dish will be nil if no available dish satisfies the customer's cravings.
+// ServiceResultEvent reports which dish a customer selected and is broadcast
+// to every player. Dish is nil if no available dish satisfies the customer.
 type ServiceResultEvent struct {
+	// Player is the ID of the restaurant that served the customer, or -1,
+	// and Money that player's money afterwards.
+	Player   int
 	Customer customer.Customer
 	Dish     *dish.Dish
 	Payment  int
 	Money    int
+	// Craving is the index of the craving the dish was graded against, or
+	// -1, and Satisfaction its grade.
+	Craving      int
+	Satisfaction Satisfaction
+	// Extras counts the dish's ingredients outside the craving, and Penalty
+	// is what they cost.
+	Extras  int
+	Penalty int
+	// Synergy is the pairing bonus included in Payment. Archetype is the
+	// dish's recognized composition and Recipe the recipe it matches, whose
+	// bonuses are included too.
+	Synergy   int
+	Archetype dish.Archetype
+	Recipe    string
+	// Doubled is set when a Double consumable doubled the payment.
+	Doubled bool
 }
 
 func (e ServiceResultEvent) EventType() string { return "service_result" }
@@ -82,37 +160,204 @@ type ServiceEndEvent struct{}
 
 func (e ServiceEndEvent) EventType() string { return "service_end" }
 
-// Action represents an input from the player relayed by the UI.
+// ConsumableGainedEvent announces that a consumable was added to the player's hand.
+type ConsumableGainedEvent struct {
+	Player     int
+	Consumable consumable.Consumable
+}
+
+func (e ConsumableGainedEvent) EventType() string { return "consumable_gained" }
+func (e ConsumableGainedEvent) PlayerID() int     { return e.Player }
+
+// ConsumableUsedEvent announces that the consumable at Index in the player's
+// hand was played and removed.
+type ConsumableUsedEvent struct {
+	Player     int
+	Consumable consumable.Consumable
+	Index      int
+}
+
+func (e ConsumableUsedEvent) EventType() string { return "consumable_used" }
+func (e ConsumableUsedEvent) PlayerID() int     { return e.Player }
+
+// CustomersPeekedEvent reveals the customers waiting at the top of the customer deck.
+type CustomersPeekedEvent struct {
+	Player    int
+	Customers []customer.Customer
+}
+
+func (e CustomersPeekedEvent) EventType() string { return "customers_peeked" }
+func (e CustomersPeekedEvent) PlayerID() int     { return e.Player }
+
+// IngredientTransmutedEvent reports that the drafted ingredient at Index was replaced.
+type IngredientTransmutedEvent struct {
+	Player int
+	Index  int
+	From   ingredient.Ingredient
+	To     ingredient.Ingredient
+}
+
+func (e IngredientTransmutedEvent) EventType() string { return "ingredient_transmuted" }
+func (e IngredientTransmutedEvent) PlayerID() int     { return e.Player }
+
+// AnteStartEvent announces a new ante and the money target that must be
+// reached by the end of its final turn. Boss describes the boss customer
+// waiting at the end of the ante and is nil if there is none.
+type AnteStartEvent struct {
+	Ante   int
+	Target int
+	Turns  int
+	Boss   *customer.BossRule
+}
+
+func (e AnteStartEvent) EventType() string { return "ante_start" }
+
+// AnteEndEvent reports whether the player reached the ante's money target.
+type AnteEndEvent struct {
+	Player int
+	Ante   int
+	Target int
+	Money  int
+	Passed bool
+}
+
+func (e AnteEndEvent) EventType() string { return "ante_end" }
+func (e AnteEndEvent) PlayerID() int     { return e.Player }
+
+// GameOverEvent signals that the game has ended for the player, with Money
+// their final money. Every player receives one, and no further events follow
+// the last.
+type GameOverEvent struct {
+	Player int
+	Turn   int
+	Ante   int
+	Money  int
+	Reason string
+}
+
+func (e GameOverEvent) EventType() string { return "game_over" }
+func (e GameOverEvent) PlayerID() int     { return e.Player }
+
+// StateSnapshotEvent holds everything the player can see of the game, so a
+// frontend can render from it and rebuild itself from it alone. Every player
+// receives one once a phase starts waiting for their actions, another
+// whenever events change their state, and another in answer to a
+// RequestSnapshotAction.
+type StateSnapshotEvent struct {
+	Player int
+	State
+}
+
+func (e StateSnapshotEvent) EventType() string { return "state_snapshot" }
+func (e StateSnapshotEvent) PlayerID() int     { return e.Player }
+
+// ActionRejectedEvent reports that the game ignored an action and why.
+type ActionRejectedEvent struct {
+	Player int
+	Action Action
+	Reason string
+}
+
+func (e ActionRejectedEvent) EventType() string { return "action_rejected" }
+func (e ActionRejectedEvent) PlayerID() int     { return e.Player }
+
+// Action represents an input from a player relayed by the UI. PlayerID
+// returns the ID of the player taking the action, which is zero in a
+// single-player game.
 type Action interface {
 	ActionType() string
+	PlayerID() int
+}
+
+// ForPlayer returns a copy of a with its player ID set to id. Actions of
+// unknown types are returned unchanged.
+func ForPlayer(a Action, id int) Action {
+	switch a := a.(type) {
+	case DraftSelectionAction:
+		a.Player = id
+		return a
+	case CreateDishAction:
+		a.Player = id
+		return a
+	case DeleteDishAction:
+		a.Player = id
+		return a
+	case FinishDesignAction:
+		a.Player = id
+		return a
+	case ContinueAction:
+		a.Player = id
+		return a
+	case UseConsumableAction:
+		a.Player = id
+		return a
+	case RequestSnapshotAction:
+		a.Player = id
+		return a
+	}
+	return a
 }
 
 // DraftSelectionAction is sent by the UI when the player selects an ingredient during drafting.
 type DraftSelectionAction struct {
-	Index int
+	Player int
+	Index  int
 }
 
 func (a DraftSelectionAction) ActionType() string { return "draft_selection" }
+func (a DraftSelectionAction) PlayerID() int      { return a.Player }
 
 // CreateDishAction contains information to create a new dish.
 type CreateDishAction struct {
+	Player  int
 	Name    string
 	Indices []int
 }
 
 func (a CreateDishAction) ActionType() string { return "create_dish" }
+func (a CreateDishAction) PlayerID() int      { return a.Player }
 
 // DeleteDishAction removes a dish at the given index.
-type DeleteDishAction struct{ Index int }
+type DeleteDishAction struct {
+	Player int
+	Index  int
+}
 
 func (a DeleteDishAction) ActionType() string { return "delete_dish" }
+func (a DeleteDishAction) PlayerID() int      { return a.Player }
 
 // FinishDesignAction signals that the player is done designing dishes.
-type FinishDesignAction struct{}
+type FinishDesignAction struct {
+	Player int
+}
 
 func (a FinishDesignAction) ActionType() string { return "finish_design" }
+func (a FinishDesignAction) PlayerID() int      { return a.Player }
 
 // ContinueAction advances the game during service or to the next turn.
-type ContinueAction struct{}
+type ContinueAction struct {
+	Player int
+}
 
 func (a ContinueAction) ActionType() string { return "continue" }
+func (a ContinueAction) PlayerID() int      { return a.Player }
+
+// RequestSnapshotAction asks for a StateSnapshotEvent. It is answered in
+// any phase and never rejected for being out of turn.
+type RequestSnapshotAction struct {
+	Player int
+}
+
+func (a RequestSnapshotAction) ActionType() string { return "request_snapshot" }
+func (a RequestSnapshotAction) PlayerID() int      { return a.Player }
+
+// UseConsumableAction plays the consumable at Index in the player's hand.
+// Target is the drafted ingredient index used by Transmute and ignored otherwise.
+type UseConsumableAction struct {
+	Player int
+	Index  int
+	Target int
+}
+
+func (a UseConsumableAction) ActionType() string { return "use_consumable" }
+func (a UseConsumableAction) PlayerID() int      { return a.Player }
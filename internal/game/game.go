@@ -1,31 +1,146 @@
 package game
 
 import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"strconv"
+	"strings"
+
 	"executive-chef/internal/customer"
 	"executive-chef/internal/deck"
+	"executive-chef/internal/pairing"
 	"executive-chef/internal/player"
+	"executive-chef/internal/recipe"
+	"executive-chef/internal/rng"
 )
 
 type Game struct {
 	Deck      *deck.Deck
 	Customers *customer.Deck
-	Player    *player.Player
-	Events    chan<- Event
-	Actions   <-chan Action
+	// Players sit around the table in order; a player's ID is their index.
+	Players []*player.Player
+	Events  chan<- Event
+	Actions <-chan Action
+	Ante    int
+	// Pairings scores ingredient synergies; nil disables synergy bonuses.
+	Pairings *pairing.Table
+	// Recipes names recognized ingredient combinations; nil disables recipes.
+	// Every player discovers recipes in their own copy of the book.
+	Recipes *recipe.Book
+	// Payouts configures what customers pay for the dishes they choose.
+	Payouts Payouts
+	// Rand seeds the game's own random choices, such as dealt consumables.
+	Rand *rand.Rand
+	// Trace receives a debug record of every engine decision: draft
+	// reveals, rejected actions, dish availability and how customers
+	// scored each dish. Nil disables tracing.
+	Trace *slog.Logger
+
+	// boss is the rule of the current ante's boss customer, if any.
+	boss *customer.BossRule
+	// stock refills the decks when they run short.
+	stock stock
+	// books holds each player's copy of the recipe book.
+	books []*recipe.Book
+	// src and rnd make the game's own random choices. They are seeded from
+	// Rand on first use and src is a plain value, so a clone draws what the
+	// game would have drawn.
+	src rng.Source
+	rnd *rand.Rand
+	// turn is the turn in progress and over reports that the game ended.
+	// pending holds the events not yet handed out, and rejection the
+	// reason the last action was rejected, if it was.
+	turn      *Turn
+	over      bool
+	pending   []Event
+	rejection string
 }
 
+// New creates a single-player game.
 func New(d *deck.Deck, c *customer.Deck, p *player.Player, events chan<- Event, actions <-chan Action) *Game {
-	return &Game{Deck: d, Customers: c, Player: p, Events: events, Actions: actions}
+	return NewMultiplayer(d, c, []*player.Player{p}, events, actions)
+}
+
+// NewMultiplayer creates a game for the given players, whose IDs are their
+// positions in players.
+func NewMultiplayer(d *deck.Deck, c *customer.Deck, players []*player.Player, events chan<- Event, actions <-chan Action) *Game {
+	return &Game{Deck: d, Customers: c, Players: players, Events: events, Actions: actions, Payouts: DefaultPayouts, Rand: rng.Global()}
 }
 
+// Play runs the game over its Events and Actions channels until a player
+// misses an ante's money target. Turns are grouped into antes of
+// TurnsPerAnte turns, the last of which ends with a boss customer. A deck
+// that runs short of ingredients, customers or bosses is topped up with a
+// fresh shuffle of the cards it started with. Play is a driver for Start and
+// Apply, which hold the rules.
 func (g *Game) Play() {
-	turn := 1
+	events := g.Start()
 	for {
-		t := Turn{Number: turn, Game: g}
-		t.DraftPhase()
-		t.DesignPhase()
-		t.ServicePhase()
-		g.Player.ResetTurn()
-		turn++
+		for _, e := range events {
+			g.Events <- e
+		}
+		if g.over {
+			return
+		}
+		_, events, _ = Apply(g, <-g.Actions)
+	}
+}
+
+// gameOver tells every player that the game ended because the players in
+// missed fell short of the ante's target.
+func (g *Game) gameOver(turn, target int, missed []int) {
+	for id, p := range g.Players {
+		reason := fmt.Sprintf("missed the ante %d target of $%d", g.Ante, target)
+		if !contains(missed, id) {
+			reason = fmt.Sprintf("%s missed the ante %d target of $%d", playerList(missed), g.Ante, target)
+		}
+		g.pending = append(g.pending, GameOverEvent{Player: id, Turn: turn, Ante: g.Ante, Money: p.Money, Reason: reason})
+	}
+	g.over = true
+}
+
+// playerList names the players with the given IDs, such as "player 2" or
+// "players 0, 1 and 3".
+func playerList(ids []int) string {
+	if len(ids) == 1 {
+		return fmt.Sprintf("player %d", ids[0])
+	}
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = strconv.Itoa(id)
+	}
+	last := len(names) - 1
+	return fmt.Sprintf("players %s and %s", strings.Join(names[:last], ", "), names[last])
+}
+
+// random returns the generator for the game's own random choices, seeding
+// it from Rand on first use.
+func (g *Game) random() *rand.Rand {
+	if g.rnd == nil {
+		g.src = rng.NewSource(g.Rand.Int63())
+		g.rnd = rand.New(&g.src)
+	}
+	return g.rnd
+}
+
+// book returns the recipe book of the player with the given ID, or nil
+// when recipes are disabled.
+func (g *Game) book(id int) *recipe.Book {
+	if g.Recipes == nil {
+		return nil
+	}
+	for len(g.books) < len(g.Players) {
+		g.books = append(g.books, recipe.NewBook(g.Recipes.Recipes))
+	}
+	return g.books[id]
+}
+
+func contains(ids []int, id int) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
 	}
+	return false
 }
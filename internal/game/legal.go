@@ -0,0 +1,121 @@
+package game
+
+import (
+	"strings"
+
+	"executive-chef/internal/consumable"
+	"executive-chef/internal/dish"
+)
+
+// Mask tells which actions the player may take next in the state's phase.
+// Draft has an entry per card of State.Reveal, DeleteDish per dish of
+// State.Menu and UseConsumable per consumable in hand.
+type Mask struct {
+	Draft         []bool
+	CreateDish    bool
+	DeleteDish    []bool
+	FinishDesign  bool
+	Continue      bool
+	UseConsumable []bool
+}
+
+// Mask returns the actions the player may take next. A waiting player may
+// take none. The engine may still reject a consumable it cannot apply, such
+// as a Transmute with nothing to turn the ingredient into, and sends a fresh
+// snapshot after the rejection.
+func (s State) Mask() Mask {
+	m := Mask{
+		Draft:         make([]bool, len(s.Reveal)),
+		DeleteDish:    make([]bool, len(s.Menu)),
+		UseConsumable: make([]bool, len(s.Consumables)),
+	}
+	if s.Waiting {
+		return m
+	}
+	switch s.Phase {
+	case PhaseDraft:
+		for i := range m.Draft {
+			m.Draft[i] = true
+		}
+	case PhaseDesign:
+		m.CreateDish = s.DishesLeft > 0
+		for i := range m.DeleteDish {
+			m.DeleteDish[i] = true
+		}
+		m.FinishDesign = true
+	case PhaseService:
+		m.Continue = true
+	}
+	for i := range m.UseConsumable {
+		m.UseConsumable[i] = s.Usable(i)
+	}
+	return m
+}
+
+// LegalActions lists every action the mask allows, with a dish for every set
+// of up to dish.MaxIngredients drafted ingredients and a Transmute for every
+// drafted target. Draft picks, FinishDesignAction and ContinueAction come
+// first, so the first legal action is always a safe default. The actions
+// carry no player ID; stamp them with ForPlayer.
+func (s State) LegalActions() []Action {
+	m := s.Mask()
+	var out []Action
+	for i, ok := range m.Draft {
+		if ok {
+			out = append(out, DraftSelectionAction{Index: i})
+		}
+	}
+	if m.FinishDesign {
+		out = append(out, FinishDesignAction{})
+	}
+	if m.Continue {
+		out = append(out, ContinueAction{})
+	}
+	if m.CreateDish {
+		for _, indices := range Combinations(len(s.Drafted), dish.MaxIngredients) {
+			names := make([]string, len(indices))
+			for i, idx := range indices {
+				names[i] = s.Drafted[idx].Name
+			}
+			out = append(out, CreateDishAction{Name: strings.Join(names, ", "), Indices: indices})
+		}
+	}
+	for i, ok := range m.DeleteDish {
+		if ok {
+			out = append(out, DeleteDishAction{Index: i})
+		}
+	}
+	for i, ok := range m.UseConsumable {
+		if !ok {
+			continue
+		}
+		if s.Consumables[i].Kind != consumable.Transmute {
+			out = append(out, UseConsumableAction{Index: i})
+			continue
+		}
+		for target := range s.Drafted {
+			out = append(out, UseConsumableAction{Index: i, Target: target})
+		}
+	}
+	return out
+}
+
+// Combinations returns every non-empty set of at most k indices below n, in
+// increasing order within each set, smaller sets first.
+func Combinations(n, k int) [][]int {
+	var out [][]int
+	var build func(start int, cur []int, size int)
+	build = func(start int, cur []int, size int) {
+		if len(cur) == size {
+			out = append(out, append([]int(nil), cur...))
+			return
+		}
+		for i := start; i < n; i++ {
+			build(i+1, append(cur, i), size)
+		}
+	}
+	for size := 1; size <= k && size <= n; size++ {
+		build(0, nil, size)
+	}
+	return out
+}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/consumable"
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+	"executive-chef/internal/rng"
+)
+
+func TestMaskFollowsPhase(t *testing.T) {
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	hand := []consumable.Consumable{{Kind: consumable.Reroll}, {Kind: consumable.Double}}
+
+	draft := State{Phase: PhaseDraft, Reveal: []ingredient.Ingredient{tofu, tofu}, Consumables: hand}
+	assert.Equal(t, Mask{
+		Draft:         []bool{true, true},
+		DeleteDish:    []bool{},
+		UseConsumable: []bool{true, false},
+	}, draft.Mask())
+
+	design := State{Phase: PhaseDesign, Menu: []MenuItem{{}}, Consumables: hand}
+	m := design.Mask()
+	assert.False(t, m.CreateDish)
+	assert.True(t, m.FinishDesign)
+	assert.Equal(t, []bool{true}, m.DeleteDish)
+	assert.Equal(t, []bool{false, true}, m.UseConsumable)
+
+	waiting := State{Phase: PhaseService, Waiting: true, Consumables: hand}
+	assert.Equal(t, Mask{Draft: []bool{}, DeleteDish: []bool{}, UseConsumable: []bool{false, false}}, waiting.Mask())
+}
+
+func TestLegalActions(t *testing.T) {
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	s := State{
+		Phase:       PhaseDesign,
+		Drafted:     []ingredient.Ingredient{tofu, rice},
+		Menu:        []MenuItem{{Dish: dish.Dish{Name: "Old"}}},
+		Consumables: []consumable.Consumable{{Kind: consumable.Transmute}},
+		DishesLeft:  1,
+	}
+	assert.Equal(t, []Action{
+		FinishDesignAction{},
+		CreateDishAction{Name: "Tofu", Indices: []int{0}},
+		CreateDishAction{Name: "Rice", Indices: []int{1}},
+		CreateDishAction{Name: "Tofu, Rice", Indices: []int{0, 1}},
+		DeleteDishAction{Index: 0},
+		UseConsumableAction{Index: 0, Target: 0},
+		UseConsumableAction{Index: 0, Target: 1},
+	}, s.LegalActions())
+
+	s.Waiting = true
+	assert.Empty(t, s.LegalActions())
+}
+
+func TestCombinations(t *testing.T) {
+	assert.Len(t, Combinations(5, 3), 5+10+10)
+	assert.Equal(t, [][]int{{0}, {1}}, Combinations(2, 1))
+	assert.Empty(t, Combinations(0, 3))
+}
+
+func TestLegalActionsAreAccepted(t *testing.T) {
+	var all []ingredient.Ingredient
+	for _, role := range []ingredient.Role{ingredient.Protein, ingredient.Carb, ingredient.Vegetable} {
+		for _, name := range []string{"A", "B", "C"} {
+			all = append(all, ingredient.Ingredient{Name: string(role) + name, Role: role})
+		}
+	}
+	r := rng.New(7)
+	players := []*player.Player{player.New(), player.New()}
+	g := NewMultiplayer(deck.New(all), customer.NewDeckFrom(r, all), players, nil, nil)
+	g.Rand = r
+
+	// The test picks its actions with its own generator, so the game's draws
+	// don't depend on them.
+	pick := rng.New(8)
+	var pending []Action
+	events := g.Start()
+	for !g.Over() {
+		for _, e := range events {
+			switch e := e.(type) {
+			case StateSnapshotEvent:
+				if legal := e.LegalActions(); len(legal) > 0 {
+					pending = append(pending, ForPlayer(legal[pick.Intn(len(legal))], e.Player))
+				}
+			case ActionRejectedEvent:
+				// Consumables may fail to apply; a fresh snapshot follows.
+				if _, ok := e.Action.(UseConsumableAction); !ok {
+					t.Fatalf("legal action rejected: %T: %s", e.Action, e.Reason)
+				}
+			}
+		}
+		require.NotEmpty(t, pending, "game stalled")
+		_, events, _ = Apply(g, pending[0])
+		pending = pending[1:]
+	}
+}
@@ -0,0 +1,220 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+)
+
+func TestDraftPhasePassesPacksAroundTheTable(t *testing.T) {
+	var cards []ingredient.Ingredient
+	for _, pack := range []string{"A", "B"} {
+		for i := 0; i < 10; i++ {
+			cards = append(cards, ingredient.Ingredient{Name: fmt.Sprintf("%s%d", pack, i), Role: ingredient.Protein})
+		}
+	}
+	players := []*player.Player{player.New(), player.New()}
+	actions := []Action{
+		DraftSelectionAction{Player: 0, Index: 0},
+		DraftSelectionAction{Player: 0, Index: 0},
+		DraftSelectionAction{Player: 5, Index: 0},
+		DraftSelectionAction{Player: 1, Index: 0},
+	}
+	for i := 0; i < 2; i++ {
+		actions = append(actions, DraftSelectionAction{Player: 1, Index: 0}, DraftSelectionAction{Player: 0, Index: 0})
+	}
+	g := NewMultiplayer(&deck.Deck{Cards: cards}, nil, players, nil, nil)
+	events := playPhase(g, &Turn{Number: 1}, PhaseDraft, actions...)
+
+	names := func(ings []ingredient.Ingredient) []string {
+		var out []string
+		for _, ing := range ings {
+			out = append(out, ing.Name)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"A0", "B1", "A2"}, names(players[0].Drafted))
+	assert.Equal(t, []string{"B0", "A1", "B2"}, names(players[1].Drafted))
+
+	var options []DraftOptionsEvent
+	var rejected []ActionRejectedEvent
+	for _, e := range events {
+		switch ev := e.(type) {
+		case DraftOptionsEvent:
+			options = append(options, ev)
+		case ActionRejectedEvent:
+			rejected = append(rejected, ev)
+		}
+	}
+	require.Len(t, options, 6)
+	assert.Equal(t, 0, options[2].Player)
+	assert.Equal(t, "B1", options[2].Reveal[0].Name)
+	assert.Equal(t, 2, options[2].Picks)
+	require.Len(t, rejected, 2)
+	assert.Equal(t, ActionRejectedEvent{Player: 0, Action: DraftSelectionAction{Player: 0}, Reason: "waiting for the other players to pick"}, rejected[0])
+	assert.Equal(t, "no player 5", rejected[1].Reason)
+}
+
+func TestDesignPhaseWaitsForEveryPlayer(t *testing.T) {
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	players := []*player.Player{player.New(), player.New()}
+	for _, p := range players {
+		p.Drafted = []ingredient.Ingredient{tofu}
+	}
+	g := NewMultiplayer(nil, nil, players, nil, nil)
+	events := playPhase(g, &Turn{Number: 1}, PhaseDesign,
+		FinishDesignAction{Player: 1},
+		CreateDishAction{Player: 1, Name: "Late", Indices: []int{0}},
+		CreateDishAction{Player: 0, Name: "Tofu", Indices: []int{0}},
+		FinishDesignAction{Player: 0},
+	)
+
+	assert.Len(t, players[0].Dishes, 1)
+	assert.Empty(t, players[1].Dishes)
+	var scoped []Event
+	for _, e := range events {
+		if _, ok := e.(StateSnapshotEvent); ok {
+			continue
+		}
+		if _, ok := e.(PlayerEvent); ok {
+			scoped = append(scoped, e)
+		}
+	}
+	require.Len(t, scoped, 4)
+	assert.Equal(t, 0, scoped[0].(DesignOptionsEvent).Player)
+	assert.Equal(t, 1, scoped[1].(DesignOptionsEvent).Player)
+	assert.Equal(t, "already finished designing", scoped[2].(ActionRejectedEvent).Reason)
+	assert.Equal(t, 0, scoped[3].(DishCreatedEvent).Player)
+}
+
+func TestPlayEndsForEveryPlayer(t *testing.T) {
+	players := []*player.Player{player.New(), player.New()}
+	events := make(chan Event)
+	actions := make(chan Action, len(players))
+	g := NewMultiplayer(&deck.Deck{}, &customer.Deck{}, players, events, actions)
+	go g.Play()
+
+	var over []GameOverEvent
+	for len(over) < len(players) {
+		switch e := (<-events).(type) {
+		case DraftOptionsEvent, DesignOptionsEvent:
+			id := e.(PlayerEvent).PlayerID()
+			if _, ok := e.(DesignOptionsEvent); ok {
+				actions <- FinishDesignAction{Player: id}
+			}
+		case GameOverEvent:
+			over = append(over, e)
+		}
+	}
+	assert.Equal(t, 0, over[0].Player)
+	assert.Equal(t, "missed the ante 1 target of $10", over[0].Reason)
+	assert.Equal(t, 1, over[1].Player)
+}
+
+func TestGameOverNamesEveryPlayerWhoMissed(t *testing.T) {
+	players := []*player.Player{player.New(), player.New(), player.New(), player.New()}
+	g := NewMultiplayer(nil, nil, players, nil, nil)
+	g.Ante = 2
+	g.gameOver(6, 25, []int{0, 1, 3})
+	require.Len(t, g.pending, 4)
+	assert.Equal(t, "missed the ante 2 target of $25", g.pending[0].(GameOverEvent).Reason)
+	assert.Equal(t, "players 0, 1 and 3 missed the ante 2 target of $25", g.pending[2].(GameOverEvent).Reason)
+
+	g.pending = nil
+	g.gameOver(6, 25, []int{3})
+	assert.Equal(t, "player 3 missed the ante 2 target of $25", g.pending[0].(GameOverEvent).Reason)
+}
+
+func TestVisibleTo(t *testing.T) {
+	assert.True(t, VisibleTo(PhaseEvent{}, 3))
+	assert.True(t, VisibleTo(DishCreatedEvent{Player: 3}, 3))
+	assert.False(t, VisibleTo(DishCreatedEvent{Player: 2}, 3))
+}
+
+func TestForPlayerSetsPlayerID(t *testing.T) {
+	a := ForPlayer(CreateDishAction{Name: "Stew", Indices: []int{1}}, 2)
+	assert.Equal(t, CreateDishAction{Player: 2, Name: "Stew", Indices: []int{1}}, a)
+	assert.Equal(t, 2, ForPlayer(ContinueAction{}, 2).PlayerID())
+}
+
+// restaurants returns players who all drafted the given ingredients and put
+// the given dishes on their menus, one menu per player.
+func restaurants(drafted []ingredient.Ingredient, menus ...[]dish.Dish) []*player.Player {
+	players := make([]*player.Player, len(menus))
+	for i, menu := range menus {
+		players[i] = player.New()
+		players[i].Drafted = drafted
+		players[i].Dishes = menu
+	}
+	return players
+}
+
+func TestServicePhaseTiesGoToTheLead(t *testing.T) {
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	plain := []dish.Dish{{Name: "Tofu", Ingredients: []ingredient.Ingredient{tofu}}}
+	patron := customer.Customer{Name: "Patron", Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{tofu}}}}
+
+	for turnNumber, lead := range map[int]int{1: 0, 2: 1, 3: 2, 4: 0} {
+		players := restaurants([]ingredient.Ingredient{tofu}, plain, plain, plain)
+		var actions []Action
+		for id := range players {
+			actions = append(actions, ContinueAction{Player: id})
+		}
+		g := NewMultiplayer(nil, &customer.Deck{Cards: []customer.Customer{patron}}, players, nil, nil)
+		turn := &Turn{Number: turnNumber, Game: g}
+		assert.Equal(t, lead, turn.Lead())
+		events := playPhase(g, turn, PhaseService, actions...)
+
+		res := events[1].(ServiceResultEvent)
+		assert.Equal(t, lead, res.Player, "turn %d", turnNumber)
+		assert.Equal(t, 5, players[lead].Money)
+	}
+}
+
+func TestServicePhaseCustomerPicksBestDishAcrossRestaurants(t *testing.T) {
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	patron := customer.Customer{Name: "Patron", Cravings: []customer.Craving{
+		{Ingredients: []ingredient.Ingredient{tofu, rice}},
+		{Ingredients: []ingredient.Ingredient{tofu}},
+	}}
+	players := restaurants([]ingredient.Ingredient{tofu, rice},
+		[]dish.Dish{{Name: "Tofu", Ingredients: []ingredient.Ingredient{tofu}}},
+		[]dish.Dish{{Name: "Tofu Bowl", Ingredients: []ingredient.Ingredient{tofu, rice}}},
+	)
+	g := NewMultiplayer(nil, &customer.Deck{Cards: []customer.Customer{patron}}, players, nil, nil)
+	events := playPhase(g, &Turn{Number: 1}, PhaseService,
+		ContinueAction{Player: 1},
+		ContinueAction{Player: 1},
+		ContinueAction{Player: 0},
+	)
+
+	var got []Event
+	for _, e := range events {
+		if _, ok := e.(StateSnapshotEvent); !ok {
+			got = append(got, e)
+		}
+		if _, ok := e.(ServiceEndEvent); ok {
+			break
+		}
+	}
+	require.Len(t, got, 4)
+	res := got[1].(ServiceResultEvent)
+	assert.Equal(t, 1, res.Player)
+	assert.Equal(t, "Tofu Bowl", res.Dish.Name)
+	assert.Equal(t, players[1].Money, res.Money)
+	assert.Zero(t, players[0].Money)
+	for id := range players {
+		assert.True(t, VisibleTo(res, id))
+	}
+	assert.Equal(t, "waiting for the other players to continue", got[2].(ActionRejectedEvent).Reason)
+	assert.IsType(t, ServiceEndEvent{}, got[3])
+}
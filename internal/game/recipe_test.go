@@ -0,0 +1,78 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+	"executive-chef/internal/recipe"
+)
+
+func TestDesignPhaseRecognizesRecipe(t *testing.T) {
+	bread := ingredient.Ingredient{Name: "Bread", Role: ingredient.Carb}
+	cheese := ingredient.Ingredient{Name: "Cheese", Role: ingredient.Dairy}
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{cheese, bread}
+	g := New(nil, nil, p, nil, nil)
+	g.Recipes = recipe.NewBook([]recipe.Recipe{
+		{Name: "Fondue", Ingredients: []string{"Cheese"}, Bonus: 1},
+		{Name: "Grilled Cheese", Ingredients: []string{"Bread", "Cheese"}, Bonus: 2},
+	})
+	events := playPhase(g, &Turn{Number: 1}, PhaseDesign,
+		CreateDishAction{Name: "Toast", Indices: []int{0, 1}},
+		FinishDesignAction{},
+	)
+
+	require.Len(t, p.Dishes, 1)
+	assert.Equal(t, "Grilled Cheese", p.Dishes[0].Name)
+	assert.True(t, g.book(0).Discovered["Grilled Cheese"])
+
+	// The phase event, design options and a snapshot come first.
+	disc := events[3].(RecipeDiscoveredEvent)
+	assert.Equal(t, 1, disc.Index)
+	assert.Equal(t, "Grilled Cheese", disc.Entry.Name)
+	created := events[4].(DishCreatedEvent)
+	assert.Equal(t, "Grilled Cheese", created.Dish.Name)
+}
+
+func TestRecipeDiscoveriesArePrivate(t *testing.T) {
+	cheese := ingredient.Ingredient{Name: "Cheese", Role: ingredient.Dairy}
+	players := []*player.Player{player.New(), player.New()}
+	players[0].Drafted = []ingredient.Ingredient{cheese}
+	g := NewMultiplayer(nil, nil, players, nil, nil)
+	g.Recipes = recipe.NewBook([]recipe.Recipe{{Name: "Fondue", Ingredients: []string{"Cheese"}, Bonus: 1}})
+	turn := &Turn{Number: 1, Game: g}
+	turn.startDesign()
+	g.pending = nil
+	turn.apply(CreateDishAction{Player: 0, Name: "Melt", Indices: []int{0}})
+
+	disc := g.pending[0].(RecipeDiscoveredEvent)
+	assert.True(t, VisibleTo(disc, 0))
+	assert.False(t, VisibleTo(disc, 1))
+	assert.True(t, turn.snapshot(0).Recipes[0].Discovered)
+	assert.False(t, turn.snapshot(1).Recipes[0].Discovered)
+	assert.Empty(t, turn.snapshot(1).Recipes[0].Name)
+}
+
+func TestServicePhaseAddsRecipeBonus(t *testing.T) {
+	cheese := ingredient.Ingredient{Name: "Cheese", Role: ingredient.Dairy}
+	cust := customer.Customer{
+		Name:     "Patron",
+		Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{cheese}}},
+	}
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{cheese}
+	p.Dishes = []dish.Dish{{Name: "Fondue", Ingredients: []ingredient.Ingredient{cheese}}}
+	g := New(nil, &customer.Deck{Cards: []customer.Customer{cust}}, p, nil, nil)
+	g.Recipes = recipe.NewBook([]recipe.Recipe{{Name: "Fondue", Ingredients: []string{"Cheese"}, Bonus: 4}})
+	events := playPhase(g, &Turn{Number: 1}, PhaseService, ContinueAction{})
+
+	sr := events[1].(ServiceResultEvent)
+	assert.Equal(t, "Fondue", sr.Recipe)
+	assert.Equal(t, 9, sr.Payment)
+}
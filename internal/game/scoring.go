@@ -0,0 +1,105 @@
+package game
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/ingredient"
+)
+
+// Satisfaction grades how completely a dish meets a craving.
+type Satisfaction string
+
+const (
+	// Full means every ingredient of the craving is in the dish.
+	Full Satisfaction = "full"
+	// Partial means some but not all ingredients of the craving are in the dish.
+	Partial Satisfaction = "partial"
+	// Mismatch means the dish shares no ingredients with any craving.
+	Mismatch Satisfaction = "mismatch"
+)
+
+// Payouts configures how much customers pay for the dish they choose.
+type Payouts struct {
+	// Ranks is the payment for fully satisfying each craving, from the most
+	// to the least desired. Cravings beyond the list pay nothing.
+	Ranks []int `yaml:"ranks"`
+	// PartialPercent is the share of the rank payment paid when a craving
+	// is only partially satisfied.
+	PartialPercent int `yaml:"partial_percent"`
+	// ExtraPenalty is deducted for each dish ingredient the craving did not ask for.
+	ExtraPenalty int `yaml:"extra_penalty"`
+}
+
+// DefaultPayouts pays $5, $3 and $1 for the first three cravings, half for
+// partial satisfaction and does not penalize extra ingredients.
+var DefaultPayouts = Payouts{Ranks: []int{5, 3, 1}, PartialPercent: 50}
+
+// LoadPayouts reads payouts from a YAML file at the given path. Fields
+// missing from the file keep their DefaultPayouts values.
+func LoadPayouts(path string) (Payouts, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Payouts{}, err
+	}
+	p := DefaultPayouts
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return Payouts{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if p.PartialPercent < 0 || p.PartialPercent > 100 {
+		return Payouts{}, fmt.Errorf("%s: partial_percent must be between 0 and 100, got %d", path, p.PartialPercent)
+	}
+	if p.ExtraPenalty < 0 {
+		return Payouts{}, fmt.Errorf("%s: extra_penalty must not be negative, got %d", path, p.ExtraPenalty)
+	}
+	return p, nil
+}
+
+// Match describes how well a dish satisfies a customer's best-served craving.
+// Payment is the craving payout less any extra-ingredient penalty, before
+// bonuses are applied.
+type Match struct {
+	Craving      int
+	Satisfaction Satisfaction
+	Extras       int
+	Payment      int
+}
+
+// Evaluate grades a dish made of ings against each of the customer's
+// cravings and returns the craving that pays best. Earlier cravings win ties.
+// Craving is -1 and Satisfaction is Mismatch when no craving is met at all.
+func (p Payouts) Evaluate(c customer.Customer, ings []ingredient.Ingredient) Match {
+	best := Match{Craving: -1, Satisfaction: Mismatch}
+	for i, cr := range c.Cravings {
+		matched := 0
+		for _, want := range cr.Ingredients {
+			for _, have := range ings {
+				if want == have {
+					matched++
+					break
+				}
+			}
+		}
+		if matched == 0 {
+			continue
+		}
+		m := Match{Craving: i, Satisfaction: Full, Extras: len(ings) - matched}
+		rank := 0
+		if i < len(p.Ranks) {
+			rank = p.Ranks[i]
+		}
+		m.Payment = rank
+		if matched < len(cr.Ingredients) {
+			m.Satisfaction = Partial
+			m.Payment = rank * p.PartialPercent / 100
+		}
+		m.Payment -= m.Extras * p.ExtraPenalty
+		if best.Craving < 0 || m.Payment > best.Payment {
+			best = m
+		}
+	}
+	return best
+}
@@ -0,0 +1,71 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+)
+
+var (
+	chicken  = ingredient.Ingredient{Name: "Chicken", Role: ingredient.Protein}
+	rice     = ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	broccoli = ingredient.Ingredient{Name: "Broccoli", Role: ingredient.Vegetable}
+)
+
+func TestEvaluateGradesSatisfaction(t *testing.T) {
+	c := customer.Customer{Cravings: []customer.Craving{
+		{Ingredients: []ingredient.Ingredient{chicken, rice}},
+		{Ingredients: []ingredient.Ingredient{broccoli}},
+	}}
+	p := Payouts{Ranks: []int{6, 4}, PartialPercent: 50, ExtraPenalty: 1}
+
+	assert.Equal(t, Match{Craving: 0, Satisfaction: Full, Payment: 6}, p.Evaluate(c, []ingredient.Ingredient{chicken, rice}))
+	assert.Equal(t, Match{Craving: 0, Satisfaction: Partial, Payment: 3}, p.Evaluate(c, []ingredient.Ingredient{chicken}))
+	assert.Equal(t, Match{Craving: 1, Satisfaction: Full, Payment: 4}, p.Evaluate(c, []ingredient.Ingredient{broccoli}))
+	assert.Equal(t, Match{Craving: 0, Satisfaction: Full, Extras: 1, Payment: 5}, p.Evaluate(c, []ingredient.Ingredient{chicken, rice, broccoli}))
+	assert.Equal(t, Match{Craving: -1, Satisfaction: Mismatch}, p.Evaluate(c, []ingredient.Ingredient{{Name: "Tofu"}}))
+}
+
+func TestLoadPayouts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "payouts.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("extra_penalty: 2\n"), 0o644))
+	p, err := LoadPayouts(path)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPayouts.Ranks, p.Ranks)
+	assert.Equal(t, DefaultPayouts.PartialPercent, p.PartialPercent)
+	assert.Equal(t, 2, p.ExtraPenalty)
+
+	require.NoError(t, os.WriteFile(path, []byte("partial_percent: 150\n"), 0o644))
+	_, err = LoadPayouts(path)
+	assert.ErrorContains(t, err, "partial_percent")
+}
+
+func TestServicePhasePrefersFullySatisfyingDish(t *testing.T) {
+	cust := customer.Customer{
+		Name:     "Patron",
+		Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{chicken, broccoli}}},
+	}
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{chicken, broccoli}
+	p.Dishes = []dish.Dish{
+		{Name: "Plain Chicken", Ingredients: []ingredient.Ingredient{chicken}},
+		{Name: "Chicken and Broccoli", Ingredients: []ingredient.Ingredient{chicken, broccoli}},
+	}
+	g := New(nil, &customer.Deck{Cards: []customer.Customer{cust}}, p, nil, nil)
+	events := playPhase(g, &Turn{Number: 1}, PhaseService, ContinueAction{})
+
+	sr := events[1].(ServiceResultEvent)
+	require.NotNil(t, sr.Dish)
+	assert.Equal(t, "Chicken and Broccoli", sr.Dish.Name)
+	assert.Equal(t, Full, sr.Satisfaction)
+	assert.Equal(t, 0, sr.Craving)
+	assert.Equal(t, 5, sr.Payment)
+}
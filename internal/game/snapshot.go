@@ -0,0 +1,97 @@
+package game
+
+import (
+	"slices"
+
+	"executive-chef/internal/customer"
+)
+
+// snapshot returns the state the player with the given ID can see. Slices are
+// copied so later moves don't change a snapshot already sent.
+func (t *Turn) snapshot(id int) StateSnapshotEvent {
+	g := t.Game
+	p := g.Players[id]
+	s := State{
+		Turn:        t.Number,
+		Phase:       t.phase,
+		Ante:        g.Ante,
+		AnteTurns:   TurnsPerAnte,
+		Target:      AnteTarget(g.Ante),
+		Boss:        g.boss,
+		Money:       p.Money,
+		Drafted:     slices.Clone(p.Drafted),
+		Consumables: slices.Clone(p.Consumables),
+		Peeked:      slices.Clone(t.peeked[id]),
+		Recipes:     g.book(id).Entries(),
+		Pairings:    g.Pairings,
+		DoubleNext:  t.doublePayment[id],
+	}
+	var created []int
+	if id < len(t.created) {
+		created = t.created[id]
+	}
+	for i, d := range p.Dishes {
+		s.Menu = append(s.Menu, MenuItem{
+			Dish: d,
+			Have: countIngredients(p.Drafted, d.Ingredients),
+			New:  slices.Contains(created, i),
+		})
+	}
+	if o, ok := t.offers[id]; ok {
+		s.Reveal = slices.Clone(o.Reveal)
+		s.Picks = o.Picks
+	}
+	switch t.phase {
+	case PhaseDraft:
+		s.Waiting = len(s.Reveal) == 0
+	case PhaseDesign:
+		s.Waiting = id < len(t.finished) && t.finished[id]
+		if !s.Waiting {
+			s.DishesLeft = min(DishesPerTurn-len(created), MaxDishes-len(p.Dishes))
+		}
+	case PhaseService:
+		s.Waiting = t.result == nil || t.continued[id]
+	}
+	if t.result != nil {
+		r := *t.result
+		s.Result = &r
+	}
+	return StateSnapshotEvent{Player: id, State: s}
+}
+
+// emit sends e and marks the players who can see it as owed a snapshot.
+func (t *Turn) emit(e Event) {
+	for id := range t.Game.Players {
+		if VisibleTo(e, id) {
+			t.touch(id)
+		}
+	}
+	t.Game.pending = append(t.Game.pending, e)
+}
+
+// touch marks the player with the given ID as owed a snapshot.
+func (t *Turn) touch(id int) {
+	if t.stale == nil {
+		t.stale = make(map[int]bool)
+	}
+	t.stale[id] = true
+}
+
+// settle queues a snapshot for every player whose state changed since their
+// last one. The turn settles whenever it waits for actions.
+func (t *Turn) settle() {
+	for id := range t.Game.Players {
+		if t.stale[id] {
+			delete(t.stale, id)
+			t.Game.pending = append(t.Game.pending, t.snapshot(id))
+		}
+	}
+}
+
+// peek records the customers the player with the given ID peeked at.
+func (t *Turn) peek(id int, customers []customer.Customer) {
+	if t.peeked == nil {
+		t.peeked = make(map[int][]customer.Customer)
+	}
+	t.peeked[id] = customers
+}
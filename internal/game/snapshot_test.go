@@ -0,0 +1,138 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/deck"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+	"executive-chef/internal/recipe"
+)
+
+// snapshots returns the snapshots among events, which must be closed.
+func snapshots(events []Event) []StateSnapshotEvent {
+	var out []StateSnapshotEvent
+	for _, e := range events {
+		if s, ok := e.(StateSnapshotEvent); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func TestPhaseSendsSnapshotsOnceWaiting(t *testing.T) {
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	players := []*player.Player{player.New(), player.New()}
+	players[1].Money = 4
+	players[1].Drafted = []ingredient.Ingredient{tofu}
+	drafted := [][]ingredient.Ingredient{players[0].Drafted, players[1].Drafted}
+	g := NewMultiplayer(nil, nil, players, nil, nil)
+	g.Ante = 1
+	events := playPhase(g, &Turn{Number: 2}, PhaseDesign,
+		FinishDesignAction{Player: 0},
+		FinishDesignAction{Player: 1},
+	)
+
+	require.Len(t, events, 6)
+	assert.Equal(t, PhaseEvent{Turn: 2, Phase: PhaseDesign}, events[0])
+	assert.IsType(t, DesignOptionsEvent{}, events[1])
+	assert.IsType(t, DesignOptionsEvent{}, events[2])
+	for id := range players {
+		s := events[3+id].(StateSnapshotEvent)
+		assert.Equal(t, id, s.Player)
+		assert.Equal(t, 2, s.Turn)
+		assert.Equal(t, PhaseDesign, s.Phase)
+		assert.Equal(t, AnteTarget(1), s.Target)
+		assert.Equal(t, players[id].Money, s.Money)
+		assert.Equal(t, drafted[id], s.Drafted)
+		assert.Equal(t, DishesPerTurn, s.DishesLeft)
+	}
+	// Player 1's snapshot after player 0 finished is not owed until the
+	// phase waits again, and it ended instead.
+	s := events[5].(StateSnapshotEvent)
+	assert.Equal(t, 0, s.Player)
+	assert.Zero(t, s.DishesLeft)
+}
+
+func TestDraftSnapshotsFollowPicks(t *testing.T) {
+	var cards []ingredient.Ingredient
+	for _, name := range []string{"A", "B", "C"} {
+		cards = append(cards, ingredient.Ingredient{Name: name, Role: ingredient.Protein})
+	}
+	p := player.New()
+	g := New(&deck.Deck{Cards: cards}, nil, p, nil, nil)
+	pick := DraftSelectionAction{Index: 0}
+	events := playPhase(g, &Turn{Number: 1}, PhaseDraft, pick, pick, pick)
+
+	got := snapshots(events)
+	require.Len(t, got, 3)
+	assert.Equal(t, cards, got[0].Reveal)
+	assert.Equal(t, 3, got[0].Picks)
+	assert.Len(t, got[0].Consumables, 1)
+	assert.Equal(t, cards[:1], got[1].Drafted)
+	assert.Equal(t, cards[1:], got[1].Reveal)
+	assert.Equal(t, 2, got[1].Picks)
+}
+
+func TestDesignSnapshotsShowMenu(t *testing.T) {
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	old := dish.Dish{Name: "Old", Ingredients: []ingredient.Ingredient{tofu, rice}}
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{tofu}
+	p.Dishes = []dish.Dish{old}
+	g := New(nil, nil, p, nil, nil)
+	events := playPhase(g, &Turn{Number: 1}, PhaseDesign,
+		CreateDishAction{Name: "Tofu", Indices: []int{0}},
+		FinishDesignAction{},
+	)
+
+	got := snapshots(events)
+	require.Len(t, got, 2)
+	assert.Equal(t, []MenuItem{{Dish: old, Have: 1}}, got[0].Menu)
+	assert.False(t, got[0].Menu[0].Available())
+	assert.Equal(t, []MenuItem{{Dish: old, Have: 1}, {Dish: p.Dishes[1], Have: 1, New: true}}, got[1].Menu)
+	assert.True(t, got[1].Menu[1].Available())
+	assert.Equal(t, 1, got[1].DishesLeft)
+}
+
+func TestRequestSnapshotIsAnsweredInAnyPhase(t *testing.T) {
+	p := player.New()
+	g := New(nil, nil, p, nil, nil)
+	events := playPhase(g, &Turn{Number: 1}, PhaseDesign, RequestSnapshotAction{}, FinishDesignAction{})
+
+	var rejected int
+	var got []StateSnapshotEvent
+	for _, e := range events {
+		switch e := e.(type) {
+		case ActionRejectedEvent:
+			rejected++
+		case StateSnapshotEvent:
+			got = append(got, e)
+		}
+	}
+	assert.Zero(t, rejected)
+	require.Len(t, got, 2)
+	assert.Equal(t, got[0], got[1])
+}
+
+func TestStatePreview(t *testing.T) {
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	s := State{
+		Drafted: []ingredient.Ingredient{tofu, rice},
+		Recipes: []recipe.Entry{{Name: "Tofu Rice", Ingredients: []string{"Tofu", "Rice"}, Discovered: true}},
+	}
+
+	p := s.Preview([]int{1, 0, 7})
+	assert.Equal(t, []ingredient.Ingredient{rice, tofu}, p.Dish.Ingredients)
+	assert.Equal(t, "Tofu Rice", p.Recipe)
+	assert.Equal(t, dish.Classify(p.Dish.Ingredients), p.Archetype)
+
+	s.Recipes[0].Discovered = false
+	assert.Empty(t, s.Preview([]int{0, 1}).Recipe)
+}
@@ -0,0 +1,95 @@
+package game
+
+import (
+	"executive-chef/internal/consumable"
+	"executive-chef/internal/customer"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/pairing"
+	"executive-chef/internal/recipe"
+)
+
+// State is everything one player can see of the game at a moment. The engine
+// never changes a State after sending it, so frontends can render from it.
+type State struct {
+	Turn        int
+	Phase       Phase
+	Ante        int
+	AnteTurns   int
+	Target      int
+	Boss        *customer.BossRule
+	Money       int
+	Drafted     []ingredient.Ingredient
+	Menu        []MenuItem
+	Consumables []consumable.Consumable
+	Peeked      []customer.Customer
+	Recipes     []recipe.Entry
+	Pairings    *pairing.Table
+	// Reveal is the draft pack awaiting the player's pick, empty while they
+	// wait for the others, and Picks the picks left this round.
+	Reveal []ingredient.Ingredient
+	Picks  int
+	// DishesLeft is the number of dishes the player may still create this
+	// design phase.
+	DishesLeft int
+	// Waiting reports that the phase needs nothing more from the player
+	// until the others catch up.
+	Waiting bool
+	// DoubleNext reports whether a Double consumable will double the
+	// player's next payment.
+	DoubleNext bool
+	// Result is the latest customer served this turn.
+	Result *ServiceResultEvent
+}
+
+// MenuItem is a dish on the player's menu. Have counts how many of its
+// ingredients the player drafted this turn, and New marks dishes created this
+// turn. A MenuItem's position in State.Menu is the index DeleteDishAction
+// takes.
+type MenuItem struct {
+	Dish dish.Dish
+	Have int
+	New  bool
+}
+
+// Available reports whether the dish will be served this turn, which needs
+// every one of its ingredients to have been drafted.
+func (m MenuItem) Available() bool {
+	return m.Have == len(m.Dish.Ingredients)
+}
+
+// Usable reports whether the consumable at index i in the player's hand may
+// be played in the current phase.
+func (s State) Usable(i int) bool {
+	return i >= 0 && i < len(s.Consumables) && UsableIn(s.Consumables[i].Kind, s.Phase)
+}
+
+// DishPreview describes a dish made from some of the player's drafted
+// ingredients: its synergy and archetype, and the discovered recipe it
+// matches, if any.
+type DishPreview struct {
+	Dish      dish.Dish
+	Synergy   int
+	Archetype dish.Archetype
+	Recipe    string
+}
+
+// Preview describes the dish made from the drafted ingredients at indices,
+// skipping indices out of range.
+func (s State) Preview(indices []int) DishPreview {
+	var d dish.Dish
+	for _, i := range indices {
+		if i >= 0 && i < len(s.Drafted) {
+			d.Ingredients = append(d.Ingredients, s.Drafted[i])
+		}
+	}
+	p := DishPreview{Dish: d, Synergy: s.Pairings.Synergy(d.Ingredients), Archetype: d.Archetype()}
+	for _, e := range s.Recipes {
+		r := recipe.Recipe{Name: e.Name, Ingredients: e.Ingredients}
+		if e.Discovered && r.Matches(d.Ingredients) {
+			p.Recipe = e.Name
+			break
+		}
+	}
+	return p
+}
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"math/rand"
+	"slices"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/ingredient"
+)
+
+// stock holds the cards the game's decks started with. A deck that runs
+// short is topped up with a fresh shuffle of them, so a game lasts as many
+// antes as its players survive.
+type stock struct {
+	ingredients []ingredient.Ingredient
+	customers   []customer.Customer
+	bosses      []customer.Customer
+}
+
+// takeStock remembers the cards the decks start with.
+func (g *Game) takeStock() {
+	g.stock = stock{}
+	if g.Deck != nil {
+		g.stock.ingredients = slices.Clone(g.Deck.Cards)
+	}
+	if g.Customers != nil {
+		g.stock.customers = slices.Clone(g.Customers.Cards)
+		g.stock.bosses = slices.Clone(g.Customers.Bosses)
+	}
+}
+
+// restockIngredients tops up the ingredient deck if it holds fewer than n
+// cards.
+func (g *Game) restockIngredients(n int) {
+	if g.Deck != nil {
+		restock(g.random(), &g.Deck.Cards, g.stock.ingredients, n)
+	}
+}
+
+// restockCustomers tops up the customer deck if it can't seat a turn's
+// customers, and the bosses if none is left for the next ante.
+func (g *Game) restockCustomers() {
+	if g.Customers != nil {
+		restock(g.random(), &g.Customers.Cards, g.stock.customers, CustomersPerTurn)
+		restock(g.random(), &g.Customers.Bosses, g.stock.bosses, 1)
+	}
+}
+
+// restock appends shuffled copies of start to pile until it holds at least
+// n cards. The cards left in pile stay on top.
+func restock[T any](r *rand.Rand, pile *[]T, start []T, n int) {
+	if len(start) == 0 {
+		return
+	}
+	for len(*pile) < n {
+		fresh := slices.Clone(start)
+		r.Shuffle(len(fresh), func(i, j int) { fresh[i], fresh[j] = fresh[j], fresh[i] })
+		*pile = append(*pile, fresh...)
+	}
+}
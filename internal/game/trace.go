@@ -0,0 +1,111 @@
+package game
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+)
+
+// tracing reports whether the game writes a debug trace. Callers check it
+// before building costly trace attributes.
+func (t *Turn) tracing() bool {
+	return t.Game.Trace != nil
+}
+
+// trace writes a debug record of an engine decision to the game's Trace
+// logger, tagged with the turn and phase.
+func (t *Turn) trace(msg string, args ...any) {
+	if t.Game.Trace == nil {
+		return
+	}
+	t.Game.Trace.Debug(msg, append([]any{slog.Int("turn", t.Number), slog.String("phase", string(t.phase))}, args...)...)
+}
+
+// traceAvailability records which of every player's dishes can be served
+// this turn and which ingredients keep the others off the menu.
+func (t *Turn) traceAvailability() {
+	if !t.tracing() {
+		return
+	}
+	for id, p := range t.Game.Players {
+		for _, d := range p.Dishes {
+			var missing []ingredient.Ingredient
+			for _, ing := range d.Ingredients {
+				if !slices.Contains(p.Drafted, ing) {
+					missing = append(missing, ing)
+				}
+			}
+			t.trace("dish availability",
+				slog.Int("player", id),
+				slog.String("dish", d.Name),
+				slog.Bool("available", len(missing) == 0),
+				slog.Any("missing", names(missing)))
+		}
+	}
+}
+
+// traceScore records how customer c judged dish d on the menu of the given
+// player: why they refused it, or how it matched their cravings.
+func (t *Turn) traceScore(c customer.Customer, id int, d dish.Dish, m *Match) {
+	if !t.tracing() {
+		return
+	}
+	args := []any{slog.String("customer", c.Name), slog.Int("player", id), slog.String("dish", d.Name)}
+	if m == nil {
+		t.trace("customer refused dish", append(args, slog.String("reason", refusal(c, d.Ingredients)))...)
+		return
+	}
+	t.trace("customer scored dish", append(args,
+		slog.Int("craving", m.Craving),
+		slog.String("satisfaction", string(m.Satisfaction)),
+		slog.Int("extras", m.Extras),
+		slog.Int("payment", m.Payment))...)
+}
+
+// traceServed records the outcome of serving a customer.
+func (t *Turn) traceServed(r ServiceResultEvent, menus [][]dish.Dish) {
+	if !t.tracing() {
+		return
+	}
+	if r.Dish == nil {
+		reason := "no dish matched a craving"
+		if slices.IndexFunc(menus, func(m []dish.Dish) bool { return len(m) > 0 }) < 0 {
+			reason = "no dish available on any menu"
+		}
+		t.trace("customer unserved", slog.String("customer", r.Customer.Name), slog.String("reason", reason))
+		return
+	}
+	t.trace("customer served",
+		slog.String("customer", r.Customer.Name),
+		slog.Int("player", r.Player),
+		slog.String("dish", r.Dish.Name),
+		slog.Int("payment", r.Payment),
+		slog.Int("synergy", r.Synergy),
+		slog.String("archetype", string(r.Archetype)),
+		slog.String("recipe", r.Recipe),
+		slog.Bool("doubled", r.Doubled))
+}
+
+// refusal explains why c won't eat a dish made of ings.
+func refusal(c customer.Customer, ings []ingredient.Ingredient) string {
+	if c.Constraint != nil && slices.Contains(ings, *c.Constraint) {
+		return fmt.Sprintf("refuses %s", c.Constraint.Name)
+	}
+	if c.Boss != nil && !c.Boss.Accepts(ings) {
+		return fmt.Sprintf("boss rule %s", c.Boss.Name)
+	}
+	return "not accepted"
+}
+
+// names lists the names of ings.
+func names(ings []ingredient.Ingredient) []string {
+	out := make([]string, len(ings))
+	for i, ing := range ings {
+		out[i] = ing.Name
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+)
+
+// traced returns a logger writing JSON records to buf.
+func traced(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// records decodes the trace written to buf.
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var out []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var r map[string]any
+		require.NoError(t, dec.Decode(&r))
+		out = append(out, r)
+	}
+	return out
+}
+
+func TestTraceExplainsUnservedCustomer(t *testing.T) {
+	chicken := ingredient.Ingredient{Name: "Chicken", Role: ingredient.Protein}
+	tomato := ingredient.Ingredient{Name: "Tomato", Role: ingredient.Vegetable}
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{chicken}
+	p.Dishes = []dish.Dish{
+		{Name: "Chicken Dish", Ingredients: []ingredient.Ingredient{chicken}},
+		{Name: "Tomato Dish", Ingredients: []ingredient.Ingredient{tomato}},
+	}
+	cust := customer.Customer{
+		Name:       "Picky",
+		Cravings:   []customer.Craving{{Ingredients: []ingredient.Ingredient{chicken}}},
+		Constraint: &chicken,
+	}
+	g := New(nil, &customer.Deck{Cards: []customer.Customer{cust}}, p, nil, nil)
+	var buf bytes.Buffer
+	g.Trace = traced(&buf)
+	playPhase(g, &Turn{Number: 2}, PhaseService, ContinueAction{})
+
+	recs := records(t, &buf)
+	require.Len(t, recs, 4)
+	assert.Equal(t, "dish availability", recs[0]["msg"])
+	assert.Equal(t, true, recs[0]["available"])
+	assert.Equal(t, false, recs[1]["available"])
+	assert.Equal(t, []any{"Tomato"}, recs[1]["missing"])
+	assert.Equal(t, "customer refused dish", recs[2]["msg"])
+	assert.Equal(t, "refuses Chicken", recs[2]["reason"])
+	assert.Equal(t, "customer unserved", recs[3]["msg"])
+	assert.Equal(t, float64(2), recs[3]["turn"])
+}
+
+func TestTraceRecordsDraftsAndRejections(t *testing.T) {
+	g := seededGame(1, 1)
+	var buf bytes.Buffer
+	g.Trace = traced(&buf)
+	g.Start()
+	_, _, err := Apply(g, ContinueAction{})
+	require.ErrorIs(t, err, ErrRejected)
+
+	recs := records(t, &buf)
+	require.Len(t, recs, 2)
+	assert.Equal(t, "draft reveal", recs[0]["msg"])
+	assert.Len(t, recs[0]["reveal"], PackSize)
+	assert.Equal(t, "action rejected", recs[1]["msg"])
+	assert.Equal(t, "continue", recs[1]["action"])
+	assert.Equal(t, "not allowed during the draft phase", recs[1]["reason"])
+}
@@ -1,213 +1,478 @@
 package game
 
 import (
+	"fmt"
+	"log/slog"
+	"slices"
 	"sort"
 
+	"executive-chef/internal/customer"
 	"executive-chef/internal/dish"
 	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
 )
 
-// Turn represents a single turn in the game.
+// CustomersPerTurn is the number of customers served each turn.
+const CustomersPerTurn = 3
+
+const (
+	// DishesPerTurn is the number of dishes a player may create each turn.
+	DishesPerTurn = 2
+	// MaxDishes is the maximum number of dishes on a player's menu.
+	MaxDishes = 10
+)
+
+// Turn represents a single turn in the game. Boss marks the final turn of an
+// ante, whose service ends with a boss customer.
 type Turn struct {
 	Number int
 	Game   *Game
+	Boss   bool
+
+	// doublePayment marks the players whose next payment is doubled by a
+	// Double consumable, until a payment has been doubled.
+	doublePayment map[int]bool
+
+	// The turn's progress through its phases: the draft packs, the picks
+	// of this turn and the current round, and the customers to serve, the
+	// menus they choose from and how many were served. complete reports
+	// that the current phase needs no more actions.
+	packs     [][]ingredient.Ingredient
+	picks     int
+	round     int
+	customers []customer.Customer
+	menus     [][]dish.Dish
+	served    int
+	complete  bool
+
+	// The rest is what players see of the turn so far, kept for snapshots:
+	// the current phase, the draft packs awaiting each player's pick, the
+	// dishes each player designed and whether they finished designing, the
+	// customers each player peeked at, the latest service result and who
+	// has continued past it. stale marks the players owed a fresh snapshot.
+	phase     Phase
+	offers    map[int]DraftOptionsEvent
+	created   [][]int
+	finished  []bool
+	peeked    map[int][]customer.Customer
+	result    *ServiceResultEvent
+	continued map[int]bool
+	stale     map[int]bool
 }
 
-// DraftPhase performs the drafting phase of a turn. Ten cards are revealed and
-// the player may draft three of them in the first turn and five thereafter.
-func (t *Turn) DraftPhase() {
-	t.Game.Events <- PhaseEvent{Turn: t.Number, Phase: PhaseDraft}
-	reveal := t.Game.Deck.Draw(10)
-	roleOrder := map[ingredient.Role]int{
-		ingredient.Protein:   0,
-		ingredient.Vegetable: 1,
-		ingredient.Carb:      2,
+const (
+	// PackSize is the number of ingredients in each player's draft pack.
+	PackSize = 10
+	// MaxPicks is the most ingredients a player drafts in one turn.
+	MaxPicks = 5
+)
+
+// startDraft begins the drafting phase of a turn. Every player is dealt a
+// pack of ten cards and may draft three ingredients in the first turn and
+// five thereafter. Each player picks one ingredient from the pack in front of
+// them, and once everyone has picked the packs are passed to the next player
+// around the table. With a single player the pack never leaves them. Players
+// are dealt a consumable at the start of the phase if their hand has room.
+func (t *Turn) startDraft() {
+	t.startPhase(PhaseDraft)
+	players := t.Game.Players
+	for id := range players {
+		t.dealConsumable(id)
+	}
+	t.Game.restockIngredients(len(players) * PackSize)
+	t.packs = make([][]ingredient.Ingredient, len(players))
+	for i := range t.packs {
+		t.packs[i] = t.Game.Deck.Draw(PackSize)
+		sortReveal(t.packs[i])
+	}
+	t.picks = 3
+	if t.Number > 1 {
+		t.picks = MaxPicks
 	}
+	t.round = 0
+	t.startRound()
+}
+
+// startRound offers a pick to every player with cards in front of them,
+// skipping rounds in which nobody has any, and completes the phase after the
+// last round.
+func (t *Turn) startRound() {
+	for ; t.round < t.picks; t.round++ {
+		t.offers = make(map[int]DraftOptionsEvent)
+		for id := range t.Game.Players {
+			if len(*t.pack(id)) > 0 {
+				t.offer(id)
+			}
+		}
+		if len(t.offers) > 0 {
+			return
+		}
+	}
+	t.offers = nil
+	t.complete = true
+}
+
+// pack returns the pack in front of the player with the given ID. Packs pass
+// one seat along each round, so in round r player p holds the pack dealt to
+// player p-r.
+func (t *Turn) pack(id int) *[]ingredient.Ingredient {
+	n := len(t.Game.Players)
+	return &t.packs[((id-t.round)%n+n)%n]
+}
+
+// offer shows the player with the given ID the pack in front of them. Packs
+// change as they are picked from, so players are shown a copy.
+func (t *Turn) offer(id int) {
+	o := DraftOptionsEvent{Player: id, Reveal: slices.Clone(*t.pack(id)), Picks: t.picks - t.round}
+	t.offers[id] = o
+	if t.tracing() {
+		t.trace("draft reveal", slog.Int("player", id), slog.Int("picks", o.Picks), slog.Any("reveal", names(o.Reveal)))
+	}
+	t.emit(o)
+}
+
+// draftAction plays an action taken by p during the draft.
+func (t *Turn) draftAction(p *player.Player, act Action) {
+	id := act.PlayerID()
+	if _, ok := t.offers[id]; !ok {
+		t.reject(act, "waiting for the other players to pick")
+		return
+	}
+	reveal := t.pack(id)
+	if use, ok := act.(UseConsumableAction); ok {
+		if err := t.useConsumable(PhaseDraft, use, reveal); err != nil {
+			t.reject(act, err.Error())
+		} else if len(*reveal) > 0 {
+			t.offer(id)
+		} else {
+			t.picked(id)
+		}
+		return
+	}
+	sel, ok := act.(DraftSelectionAction)
+	if !ok {
+		t.reject(act, "not allowed during the draft phase")
+		return
+	}
+	if sel.Index < 0 || sel.Index >= len(*reveal) {
+		t.reject(act, fmt.Sprintf("no draftable ingredient at index %d", sel.Index))
+		return
+	}
+	chosen := (*reveal)[sel.Index]
+	p.Add(chosen)
+	t.emit(IngredientDraftedEvent{Player: id, Ingredient: chosen})
+	*reveal = append((*reveal)[:sel.Index], (*reveal)[sel.Index+1:]...)
+	t.picked(id)
+}
+
+// picked records that the player with the given ID is done with this round,
+// and starts the next round once every player is.
+func (t *Turn) picked(id int) {
+	delete(t.offers, id)
+	if len(t.offers) == 0 {
+		t.round++
+		t.startRound()
+	}
+}
+
+// startPhase announces phase. Every player is sent a snapshot of it once the
+// phase waits for their actions.
+func (t *Turn) startPhase(phase Phase) {
+	t.phase = phase
+	t.complete = false
+	t.emit(PhaseEvent{Turn: t.Number, Phase: phase})
+}
+
+// sortReveal orders draftable ingredients by role, following the order of
+// the role registry, and then by name.
+func sortReveal(reveal []ingredient.Ingredient) {
 	sort.Slice(reveal, func(i, j int) bool {
-		ri := roleOrder[reveal[i].Role]
-		rj := roleOrder[reveal[j].Role]
+		ri := reveal[i].Role.Order()
+		rj := reveal[j].Role.Order()
 		if ri != rj {
 			return ri < rj
 		}
 		return reveal[i].Name < reveal[j].Name
 	})
-	remaining := 3
-	if t.Number > 1 {
-		remaining = 5
-	}
-	t.Game.Events <- DraftOptionsEvent{Reveal: reveal, Picks: remaining}
-	for remaining > 0 && len(reveal) > 0 {
-		act := <-t.Game.Actions
-		sel, ok := act.(DraftSelectionAction)
-		if !ok || sel.Index < 0 || sel.Index >= len(reveal) {
-			continue
-		}
-		chosen := reveal[sel.Index]
-		t.Game.Player.Add(chosen)
-		t.Game.Events <- IngredientDraftedEvent{Ingredient: chosen}
-		reveal = append(reveal[:sel.Index], reveal[sel.Index+1:]...)
-		remaining--
-		if remaining > 0 && len(reveal) > 0 {
-			t.Game.Events <- DraftOptionsEvent{Reveal: reveal, Picks: remaining}
-		}
-	}
-}
-
-// DesignPhase allows the player to combine drafted ingredients into named dishes.
-// The player can create up to two dishes this turn and may have up to ten dishes
-// overall. Each dish may contain at most three ingredients. The phase ends when
-// a FinishDesignAction is received.
-func (t *Turn) DesignPhase() {
-	t.Game.Events <- PhaseEvent{Turn: t.Number, Phase: PhaseDesign}
-	t.Game.Events <- DesignOptionsEvent{Drafted: t.Game.Player.Drafted}
-	created := []int{}
-
-	for {
-		act := <-t.Game.Actions
-		switch a := act.(type) {
-		case CreateDishAction:
-			if a.Name == "" || len(created) >= 2 || len(t.Game.Player.Dishes) >= 10 || len(a.Indices) > dish.MaxIngredients {
-				continue
+}
+
+// startDesign begins the design phase, in which players combine drafted
+// ingredients into named dishes. Each player can create up to two dishes
+// this turn and may have up to ten dishes overall. Each dish may contain at
+// most three ingredients. Dishes matching a recipe take the recipe's name and
+// discover it. Players design at the same time, and the phase ends once
+// every player has sent a FinishDesignAction.
+func (t *Turn) startDesign() {
+	t.created = make([][]int, len(t.Game.Players))
+	t.finished = make([]bool, len(t.Game.Players))
+	t.startPhase(PhaseDesign)
+	for id, p := range t.Game.Players {
+		t.emit(DesignOptionsEvent{Player: id, Drafted: p.Drafted, Pairings: t.Game.Pairings})
+	}
+	t.complete = len(t.Game.Players) == 0
+}
+
+// designAction plays an action taken by p during the design phase.
+func (t *Turn) designAction(p *player.Player, act Action) {
+	id := act.PlayerID()
+	if t.finished[id] {
+		t.reject(act, "already finished designing")
+		return
+	}
+	switch a := act.(type) {
+	case CreateDishAction:
+		dishIngs, reason := t.dishIngredients(p, a, len(t.created[id]))
+		if reason != "" {
+			t.reject(act, reason)
+			return
+		}
+		d := dish.Dish{Name: a.Name, Ingredients: dishIngs}
+		if r, ok := t.Game.Recipes.Match(dishIngs); ok {
+			d.Name = r.Name
+			if book := t.Game.book(id); book.Discover(r.Name) {
+				t.emit(RecipeDiscoveredEvent{Player: id, Index: book.Index(r.Name), Entry: r.Entry()})
 			}
-			used := make(map[int]bool)
-			var dishIngs []ingredient.Ingredient
-			valid := true
-			for _, idx := range a.Indices {
-				if idx < 0 || idx >= len(t.Game.Player.Drafted) || used[idx] {
-					valid = false
+		}
+		p.AddDish(d)
+		t.created[id] = append(t.created[id], len(p.Dishes)-1)
+		t.emit(DishCreatedEvent{Player: id, Dish: d})
+	case DeleteDishAction:
+		if a.Index < 0 || a.Index >= len(p.Dishes) {
+			t.reject(act, fmt.Sprintf("no dish at index %d", a.Index))
+			return
+		}
+		d, ok := p.RemoveDish(a.Index)
+		if ok {
+			mine := t.created[id]
+			for i, idx := range mine {
+				if idx == a.Index {
+					mine = append(mine[:i], mine[i+1:]...)
 					break
 				}
-				used[idx] = true
-				dishIngs = append(dishIngs, t.Game.Player.Drafted[idx])
-			}
-			if !valid || len(dishIngs) == 0 || len(dishIngs) > dish.MaxIngredients {
-				continue
-			}
-			d := dish.Dish{Name: a.Name, Ingredients: dishIngs}
-			t.Game.Player.AddDish(d)
-			created = append(created, len(t.Game.Player.Dishes)-1)
-			t.Game.Events <- DishCreatedEvent{Dish: d}
-		case DeleteDishAction:
-			if a.Index < 0 || a.Index >= len(t.Game.Player.Dishes) {
-				continue
 			}
-			d, ok := t.Game.Player.RemoveDish(a.Index)
-			if ok {
-				for i, idx := range created {
-					if idx == a.Index {
-						created = append(created[:i], created[i+1:]...)
-						break
-					}
-				}
-				for i := range created {
-					if created[i] > a.Index {
-						created[i]--
-					}
+			for i := range mine {
+				if mine[i] > a.Index {
+					mine[i]--
 				}
-				t.Game.Events <- DishDeletedEvent{Dish: d, Index: a.Index}
 			}
-		case FinishDesignAction:
-			return
+			t.created[id] = mine
+			t.emit(DishDeletedEvent{Player: id, Dish: d, Index: a.Index})
+		}
+	case UseConsumableAction:
+		if err := t.useConsumable(PhaseDesign, a, nil); err != nil {
+			t.reject(act, err.Error())
 		}
+	case FinishDesignAction:
+		t.finished[id] = true
+		t.touch(id)
+		t.complete = !slices.Contains(t.finished, false)
+	default:
+		t.reject(act, "not allowed during the design phase")
 	}
 }
 
-// ServicePhase presents dishes to customers who choose based on their cravings.
-func (t *Turn) ServicePhase() {
-	t.Game.Events <- PhaseEvent{Turn: t.Number, Phase: PhaseService}
-	customers := t.Game.Customers.Draw(3)
-	var available []dish.Dish
-	for _, d := range t.Game.Player.Dishes {
-		if hasIngredients(t.Game.Player.Drafted, d.Ingredients) {
-			available = append(available, d)
+// startService seats the turn's customers, with the boss last on a boss turn,
+// and serves the first. Each result is broadcast, and the next customer
+// arrives once every player has continued.
+func (t *Turn) startService() {
+	t.startPhase(PhaseService)
+	t.Game.restockCustomers()
+	t.customers = t.Game.Customers.Draw(CustomersPerTurn)
+	if t.Boss {
+		if b, ok := t.Game.Customers.DrawBoss(); ok {
+			t.customers = append(t.customers, b)
 		}
 	}
-	for i, c := range customers {
-		bestIdx := -1
-		bestScore := 0
-		bestCraving := -1
-		for i, d := range available {
-			if c.Constraint != nil {
-				rejected := false
-				for _, ing := range d.Ingredients {
-					if ing == *c.Constraint {
-						rejected = true
-						break
-					}
-				}
-				if rejected {
-					continue
-				}
-			}
-			score := 0
-			cravingIdx := -1
-			for j, cr := range c.Cravings {
-				count := 0
-				for _, ing := range cr.Ingredients {
-					for _, ding := range d.Ingredients {
-						if ing == ding {
-							count++
-							break
-						}
-					}
-				}
-				if count > score {
-					score = count
-					cravingIdx = j
-				}
-			}
-			if score > bestScore {
-				bestScore = score
-				bestIdx = i
-				bestCraving = cravingIdx
+	t.menus = make([][]dish.Dish, len(t.Game.Players))
+	for id, p := range t.Game.Players {
+		for _, d := range p.Dishes {
+			if hasIngredients(p.Drafted, d.Ingredients) {
+				t.menus[id] = append(t.menus[id], d)
 			}
 		}
-		var chosen *dish.Dish
-		payment := 0
-		if bestIdx >= 0 && bestScore > 0 {
-			d := available[bestIdx]
-			chosen = &d
-			switch bestCraving {
-			case 0:
-				payment = 5
-			case 1:
-				payment = 3
-			case 2:
-				payment = 1
-			}
-			t.Game.Player.AddMoney(payment)
+	}
+	t.traceAvailability()
+	t.served = 0
+	t.serveNext()
+}
+
+// serveNext serves the next customer, or ends the phase once every customer
+// has been served.
+func (t *Turn) serveNext() {
+	if t.served == len(t.customers) {
+		t.emit(ServiceEndEvent{})
+		t.complete = true
+		return
+	}
+	result := t.serve(t.customers[t.served], t.menus)
+	t.traceServed(result, t.menus)
+	t.served++
+	t.result = &result
+	t.continued = make(map[int]bool)
+	t.emit(result)
+}
+
+// serviceAction plays an action taken during service. The next customer
+// arrives once every player has sent a ContinueAction, and consumables
+// played in the meantime are applied to the service phase.
+func (t *Turn) serviceAction(act Action) {
+	switch a := act.(type) {
+	case ContinueAction:
+		if t.continued[a.Player] {
+			t.reject(act, "waiting for the other players to continue")
+			return
 		}
-		t.Game.Events <- ServiceResultEvent{Customer: c, Dish: chosen, Payment: payment, Money: t.Game.Player.Money}
-		if i < len(customers)-1 {
-			for {
-				if _, ok := (<-t.Game.Actions).(ContinueAction); ok {
-					break
-				}
-			}
+		t.continued[a.Player] = true
+		if len(t.continued) == len(t.Game.Players) {
+			t.serveNext()
 		}
+	case UseConsumableAction:
+		if err := t.useConsumable(PhaseService, a, nil); err != nil {
+			t.reject(act, err.Error())
+		}
+	default:
+		t.reject(act, "not allowed during the service phase")
+	}
+}
+
+// Lead returns the ID of the player who leads the turn's service and wins
+// ties between restaurants. The lead passes to the next player each turn.
+func (t *Turn) Lead() int {
+	if len(t.Game.Players) == 0 || t.Number < 1 {
+		return 0
 	}
-	if len(customers) > 0 {
-		for {
-			if _, ok := (<-t.Game.Actions).(ContinueAction); ok {
-				break
+	return (t.Number - 1) % len(t.Game.Players)
+}
+
+// serve plays one trick: c picks the dish that pays best across every menu,
+// with ties going to the restaurant nearest the lead, and its restaurant is
+// paid at least $1 with the dish's bonuses included.
+func (t *Turn) serve(c customer.Customer, menus [][]dish.Dish) ServiceResultEvent {
+	winner := -1
+	var chosen *dish.Dish
+	best := Match{Craving: -1, Satisfaction: Mismatch}
+	for k := range menus {
+		id := (t.Lead() + k) % len(menus)
+		for i, d := range menus[id] {
+			if !c.Accepts(d.Ingredients) {
+				t.traceScore(c, id, d, nil)
+				continue
+			}
+			m := t.Game.Payouts.Evaluate(c, d.Ingredients)
+			t.traceScore(c, id, d, &m)
+			if m.Satisfaction == Mismatch {
+				continue
+			}
+			if chosen == nil || m.Payment > best.Payment {
+				winner = id
+				chosen = &menus[id][i]
+				best = m
 			}
 		}
 	}
-	t.Game.Events <- ServiceEndEvent{}
+	result := ServiceResultEvent{
+		Player:       winner,
+		Customer:     c,
+		Craving:      best.Craving,
+		Satisfaction: best.Satisfaction,
+		Extras:       best.Extras,
+		Penalty:      best.Extras * t.Game.Payouts.ExtraPenalty,
+		Archetype:    dish.NoArchetype,
+	}
+	if chosen == nil {
+		return result
+	}
+	d := *chosen
+	p := t.Game.Players[winner]
+	result.Dish = &d
+	result.Synergy = t.Game.Pairings.Synergy(d.Ingredients)
+	result.Archetype = d.Archetype()
+	payment := best.Payment + result.Synergy + result.Archetype.Bonus()
+	if r, ok := t.Game.Recipes.Match(d.Ingredients); ok {
+		result.Recipe = r.Name
+		payment += r.Bonus
+	}
+	if payment < 1 {
+		payment = 1
+	}
+	if t.doublePayment[winner] {
+		payment *= 2
+		result.Doubled = true
+		delete(t.doublePayment, winner)
+	}
+	p.AddMoney(payment)
+	result.Payment = payment
+	result.Money = p.Money
+	return result
+}
+
+// dishIngredients validates a CreateDishAction from p given the number of
+// dishes p already created this turn. It returns the dish's ingredients, or a
+// reason the dish cannot be created.
+func (t *Turn) dishIngredients(p *player.Player, a CreateDishAction, created int) ([]ingredient.Ingredient, string) {
+	switch {
+	case a.Name == "":
+		return nil, "dish name is empty"
+	case created >= DishesPerTurn:
+		return nil, fmt.Sprintf("already created %d dishes this turn", DishesPerTurn)
+	case len(p.Dishes) >= MaxDishes:
+		return nil, fmt.Sprintf("menu already has %d dishes", MaxDishes)
+	case len(a.Indices) == 0:
+		return nil, "dish has no ingredients"
+	case len(a.Indices) > dish.MaxIngredients:
+		return nil, fmt.Sprintf("dish has more than %d ingredients", dish.MaxIngredients)
+	}
+	used := make(map[int]bool)
+	var dishIngs []ingredient.Ingredient
+	for _, idx := range a.Indices {
+		if idx < 0 || idx >= len(p.Drafted) {
+			return nil, fmt.Sprintf("no drafted ingredient at index %d", idx)
+		}
+		if used[idx] {
+			return nil, fmt.Sprintf("drafted ingredient %d used twice", idx)
+		}
+		used[idx] = true
+		dishIngs = append(dishIngs, p.Drafted[idx])
+	}
+	return dishIngs, ""
+}
+
+// reject reports to the acting player that an action was ignored and why.
+// The player is owed a snapshot, so one that decides on snapshots gets to
+// choose again.
+func (t *Turn) reject(a Action, reason string) {
+	t.Game.pending = append(t.Game.pending, ActionRejectedEvent{Player: a.PlayerID(), Action: a, Reason: reason})
+	t.Game.rejection = reason
+	if t.tracing() {
+		t.trace("action rejected", slog.Int("player", a.PlayerID()), slog.String("action", a.ActionType()), slog.Any("data", a), slog.String("reason", reason))
+	}
+	if id := a.PlayerID(); id >= 0 && id < len(t.Game.Players) {
+		t.touch(id)
+	}
+}
+
+// player returns the player taking a, rejecting the action if its player ID
+// is not at the table.
+func (t *Turn) player(a Action) (*player.Player, bool) {
+	id := a.PlayerID()
+	if id < 0 || id >= len(t.Game.Players) {
+		t.reject(a, fmt.Sprintf("no player %d", id))
+		return nil, false
+	}
+	return t.Game.Players[id], true
 }
 
 func hasIngredients(have []ingredient.Ingredient, needed []ingredient.Ingredient) bool {
+	return countIngredients(have, needed) == len(needed)
+}
+
+// countIngredients counts the ingredients in needed that appear in have.
+func countIngredients(have []ingredient.Ingredient, needed []ingredient.Ingredient) int {
+	count := 0
 	for _, n := range needed {
-		found := false
-		for _, h := range have {
-			if h == n {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
+		if slices.Contains(have, n) {
+			count++
 		}
 	}
-	return true
+	return count
 }
@@ -2,14 +2,15 @@ package game
 
 import (
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"executive-chef/internal/customer"
 	"executive-chef/internal/deck"
 	"executive-chef/internal/dish"
 	"executive-chef/internal/ingredient"
+	"executive-chef/internal/pairing"
 	"executive-chef/internal/player"
 )
 
@@ -40,14 +41,12 @@ func TestDraftPhaseAllowsFivePicksAfterFirstTurn(t *testing.T) {
 	}
 	d := &deck.Deck{Cards: reveal}
 	p := player.New()
-	events := make(chan Event, 20)
-	actions := make(chan Action, 5)
-	for i := 0; i < 5; i++ {
-		actions <- DraftSelectionAction{Index: 0}
+	var picks []Action
+	for i := 0; i < 6; i++ {
+		picks = append(picks, DraftSelectionAction{Index: 0})
 	}
-	g := New(d, nil, p, events, actions)
-	turn := Turn{Number: 2, Game: g}
-	turn.DraftPhase()
+	g := New(d, nil, p, nil, nil)
+	playPhase(g, &Turn{Number: 2}, PhaseDraft, picks...)
 	assert.Len(t, p.Drafted, 5)
 }
 
@@ -61,28 +60,17 @@ func TestServicePhaseWaitsForFinalContinue(t *testing.T) {
 	p := player.New()
 	p.Drafted = []ingredient.Ingredient{ing}
 	p.Dishes = []dish.Dish{{Name: "Dish", Ingredients: []ingredient.Ingredient{ing}}}
-	events := make(chan Event, 10)
-	actions := make(chan Action, 1)
-	g := New(nil, cdeck, p, events, actions)
-	turn := Turn{Number: 1, Game: g}
+	g := New(nil, cdeck, p, nil, nil)
+	events := playPhase(g, &Turn{Number: 1}, PhaseService)
 
-	go turn.ServicePhase()
+	require.Len(t, events, 3)
+	assert.IsType(t, PhaseEvent{}, events[0])
+	assert.IsType(t, ServiceResultEvent{}, events[1])
+	assert.IsType(t, StateSnapshotEvent{}, events[2], "waiting for continue")
 
-	<-events // PhaseEvent
-	if _, ok := (<-events).(ServiceResultEvent); !ok {
-		t.Fatal("expected service result event")
-	}
-
-	select {
-	case e := <-events:
-		t.Fatalf("unexpected event before continue: %T", e)
-	case <-time.After(10 * time.Millisecond):
-	}
-
-	actions <- ContinueAction{}
-	if _, ok := (<-events).(ServiceEndEvent); !ok {
-		t.Fatal("expected service end event")
-	}
+	_, events, err := Apply(g, ContinueAction{})
+	require.NoError(t, err)
+	assert.IsType(t, ServiceEndEvent{}, events[0])
 }
 
 func TestDesignPhaseRejectsDishesWithMoreThanThreeIngredients(t *testing.T) {
@@ -93,21 +81,11 @@ func TestDesignPhaseRejectsDishesWithMoreThanThreeIngredients(t *testing.T) {
 		{Name: "Ing3", Role: ingredient.Protein},
 		{Name: "Ing4", Role: ingredient.Protein},
 	}
-	events := make(chan Event, 10)
-	actions := make(chan Action, 10)
-	g := New(nil, nil, p, events, actions)
-	turn := Turn{Number: 1, Game: g}
-
-	done := make(chan struct{})
-	go func() {
-		turn.DesignPhase()
-		close(done)
-	}()
-
-	actions <- CreateDishAction{Name: "TooMany", Indices: []int{0, 1, 2, 3}}
-	actions <- FinishDesignAction{}
-	<-done
-
+	g := New(nil, nil, p, nil, nil)
+	playPhase(g, &Turn{Number: 1}, PhaseDesign,
+		CreateDishAction{Name: "TooMany", Indices: []int{0, 1, 2, 3}},
+		FinishDesignAction{},
+	)
 	assert.Empty(t, p.Dishes)
 }
 
@@ -125,17 +103,87 @@ func TestServicePhaseRejectsNonMatchingDish(t *testing.T) {
 	}
 	customers := &customer.Deck{Cards: []customer.Customer{cust}}
 
-	events := make(chan Event, 3)
-	actions := make(chan Action, 1)
-	actions <- ContinueAction{}
+	g := New(nil, customers, p, nil, nil)
+	events := playPhase(g, &Turn{Number: 1}, PhaseService, ContinueAction{})
 
-	g := New(nil, customers, p, events, actions)
-	turn := Turn{Number: 1, Game: g}
-	turn.ServicePhase()
-
-	<-events // phase event
-	sr := (<-events).(ServiceResultEvent)
+	sr := events[1].(ServiceResultEvent)
 	assert.Nil(t, sr.Dish)
 	assert.Equal(t, 0, sr.Payment)
 	assert.Equal(t, 0, p.Money)
 }
+
+func TestSortRevealFollowsRoleRegistry(t *testing.T) {
+	reveal := []ingredient.Ingredient{
+		{Name: "Parsley", Role: ingredient.Garnish},
+		{Name: "Rice", Role: ingredient.Carb},
+		{Name: "Cheese", Role: ingredient.Dairy},
+		{Name: "Beef", Role: ingredient.Protein},
+		{Name: "Carrot", Role: ingredient.Vegetable},
+	}
+	sortReveal(reveal)
+	var names []string
+	for _, ing := range reveal {
+		names = append(names, ing.Name)
+	}
+	assert.Equal(t, []string{"Beef", "Carrot", "Rice", "Cheese", "Parsley"}, names)
+}
+
+func TestServicePhaseAddsPairingSynergy(t *testing.T) {
+	salmon := ingredient.Ingredient{Name: "Salmon", Role: ingredient.Protein}
+	lemon := ingredient.Ingredient{Name: "Lemon", Role: ingredient.Fruit}
+	cust := customer.Customer{
+		Name:     "Patron",
+		Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{salmon}}},
+	}
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{salmon, lemon}
+	p.Dishes = []dish.Dish{{Name: "Salmon with Lemon", Ingredients: []ingredient.Ingredient{salmon, lemon}}}
+	g := New(nil, &customer.Deck{Cards: []customer.Customer{cust}}, p, nil, nil)
+	g.Pairings = &pairing.Table{Pairings: []pairing.Pairing{
+		{Ingredients: [2]string{"Salmon", "Lemon"}, Affinity: pairing.Great},
+	}}
+	events := playPhase(g, &Turn{Number: 1}, PhaseService, ContinueAction{})
+
+	sr := events[1].(ServiceResultEvent)
+	assert.Equal(t, 3, sr.Synergy)
+	assert.Equal(t, 8, sr.Payment)
+	assert.Equal(t, 8, p.Money)
+}
+
+func TestServicePhaseAddsArchetypeBonus(t *testing.T) {
+	beef := ingredient.Ingredient{Name: "Beef", Role: ingredient.Protein}
+	potato := ingredient.Ingredient{Name: "Potato", Role: ingredient.Carb}
+	carrot := ingredient.Ingredient{Name: "Carrot", Role: ingredient.Vegetable}
+	cust := customer.Customer{
+		Name:     "Patron",
+		Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{beef}}},
+	}
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{beef, potato, carrot}
+	p.Dishes = []dish.Dish{{Name: "Beef Plate", Ingredients: []ingredient.Ingredient{beef, potato, carrot}}}
+	g := New(nil, &customer.Deck{Cards: []customer.Customer{cust}}, p, nil, nil)
+	events := playPhase(g, &Turn{Number: 1}, PhaseService, ContinueAction{})
+
+	sr := events[1].(ServiceResultEvent)
+	assert.Equal(t, dish.Plate, sr.Archetype)
+	assert.Equal(t, 5+dish.Plate.Bonus(), sr.Payment)
+}
+
+func TestDesignPhaseReportsRejectedActions(t *testing.T) {
+	p := player.New()
+	p.Drafted = []ingredient.Ingredient{{Name: "Chicken", Role: ingredient.Protein}}
+	g := New(nil, nil, p, nil, nil)
+	events := playPhase(g, &Turn{Number: 1}, PhaseDesign,
+		CreateDishAction{Name: "Ghost", Indices: []int{4}},
+		ContinueAction{},
+		FinishDesignAction{},
+	)
+
+	// Phase, design options and a snapshot come first.
+	rej := events[3].(ActionRejectedEvent)
+	assert.Equal(t, "no drafted ingredient at index 4", rej.Reason)
+	assert.IsType(t, StateSnapshotEvent{}, events[4])
+	rej = events[5].(ActionRejectedEvent)
+	assert.Equal(t, ContinueAction{}, rej.Action)
+	assert.Empty(t, p.Dishes)
+}
@@ -0,0 +1,97 @@
+package headless
+
+import (
+	"errors"
+	"fmt"
+
+	"executive-chef/internal/game"
+)
+
+// ErrStalled is returned when the game waits for an action but no strategy
+// has one to send, usually because a strategy did not answer an event the
+// game was waiting on, or when a strategy keeps sending actions the game
+// rejects.
+var ErrStalled = errors.New("headless: game stalled waiting for an action")
+
+// maxRejections is the number of actions in a row a player may have rejected
+// before RunTable gives up on the game as stalled.
+const maxRejections = 3
+
+// Result summarizes a finished game.
+type Result struct {
+	Turns  int
+	Ante   int
+	Money  int
+	Reason string
+	Events int
+}
+
+// Run plays g to completion with s choosing every action and returns the
+// outcome once the game is over.
+func Run(g *game.Game, s Strategy) (Result, error) {
+	res, err := RunTable(g, []Strategy{s})
+	if err != nil {
+		return Result{}, err
+	}
+	return res[0], nil
+}
+
+// RunTable plays a game with one strategy per player, strategies[i] playing
+// the player with ID i, and returns every player's outcome once the game is
+// over. Each strategy only sees the events visible to its player, and the
+// actions it returns are sent on that player's behalf. The game is stepped
+// with game.Apply in the calling goroutine; g's Events and Actions channels
+// are not used.
+func RunTable(g *game.Game, strategies []Strategy) ([]Result, error) {
+	if len(strategies) != len(g.Players) {
+		return nil, fmt.Errorf("headless: %d strategies for %d players", len(strategies), len(g.Players))
+	}
+	var (
+		results  = make([]Result, len(strategies))
+		rejected = make([]int, len(strategies))
+		over     = 0
+		pending  []game.Action
+	)
+	events := g.Start()
+	for {
+		for _, e := range events {
+			for id, s := range strategies {
+				if !game.VisibleTo(e, id) {
+					continue
+				}
+				results[id].Events++
+				a := s.Act(e)
+				if end, ok := e.(game.GameOverEvent); ok {
+					results[id].Turns = end.Turn
+					results[id].Ante = end.Ante
+					results[id].Money = end.Money
+					results[id].Reason = end.Reason
+					if over++; over == len(strategies) {
+						return results, nil
+					}
+					continue
+				}
+				if a != nil {
+					pending = append(pending, game.ForPlayer(a, id))
+				}
+			}
+		}
+		if len(pending) == 0 {
+			return nil, ErrStalled
+		}
+		a := pending[0]
+		pending = pending[1:]
+		var err error
+		_, events, err = game.Apply(g, a)
+		switch id := a.PlayerID(); {
+		case errors.Is(err, game.ErrRejected):
+			if rejected[id]++; rejected[id] >= maxRejections {
+				return nil, fmt.Errorf("%w: player %d, %d actions in a row: %w", ErrStalled, id, rejected[id], err)
+			}
+		case err != nil:
+			return nil, err
+		default:
+			rejected[id] = 0
+		}
+	}
+}
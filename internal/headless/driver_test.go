@@ -0,0 +1,80 @@
+package headless_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+)
+
+func newGame(t *testing.T) *game.Game {
+	t.Helper()
+	all := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein},
+		{Name: "Rice", Role: ingredient.Carb},
+		{Name: "Broccoli", Role: ingredient.Vegetable},
+	}
+	return game.New(deck.New(all), customer.NewDeck(all), player.New(), nil, nil)
+}
+
+func TestRunPlaysFullGame(t *testing.T) {
+	g := newGame(t)
+	res, err := headless.Run(g, &headless.FirstChoice{})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, res.Turns, game.TurnsPerAnte)
+	assert.Equal(t, g.Players[0].Money, res.Money)
+	assert.NotEmpty(t, res.Reason)
+	assert.Greater(t, res.Events, 0)
+}
+
+func TestRunReportsStall(t *testing.T) {
+	g := newGame(t)
+	idle := headless.StrategyFunc(func(game.Event) game.Action { return nil })
+	_, err := headless.Run(g, idle)
+	assert.ErrorIs(t, err, headless.ErrStalled)
+}
+
+func TestRunReportsStrategyStuckOnRejections(t *testing.T) {
+	g := newGame(t)
+	stubborn := headless.StrategyFunc(func(e game.Event) game.Action {
+		if _, ok := e.(game.StateSnapshotEvent); ok {
+			return game.ContinueAction{}
+		}
+		return nil
+	})
+	_, err := headless.Run(g, stubborn)
+	assert.ErrorIs(t, err, headless.ErrStalled)
+	assert.ErrorIs(t, err, game.ErrRejected)
+}
+
+func TestRunTablePlaysEveryPlayer(t *testing.T) {
+	all := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein},
+		{Name: "Rice", Role: ingredient.Carb},
+		{Name: "Broccoli", Role: ingredient.Vegetable},
+	}
+	players := []*player.Player{player.New(), player.New(), player.New()}
+	g := game.NewMultiplayer(deck.New(all), customer.NewDeck(all), players, nil, nil)
+	strategies := []headless.Strategy{&headless.FirstChoice{}, &headless.FirstChoice{}, &headless.FirstChoice{}}
+
+	res, err := headless.RunTable(g, strategies)
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	for id, r := range res {
+		assert.Equal(t, players[id].Money, r.Money)
+		assert.NotEmpty(t, r.Reason)
+		assert.Equal(t, res[0].Turns, r.Turns)
+	}
+}
+
+func TestRunTableNeedsOneStrategyPerPlayer(t *testing.T) {
+	_, err := headless.RunTable(newGame(t), nil)
+	assert.EqualError(t, err, "headless: 0 strategies for 1 players")
+}
@@ -0,0 +1,65 @@
+package headless
+
+import (
+	"fmt"
+
+	"executive-chef/internal/dish"
+	"executive-chef/internal/game"
+)
+
+// Strategy chooses a player's actions from the events the game sends.
+type Strategy interface {
+	// Act is called with every event in order. It returns the action to
+	// send in response, or nil if the event needs no response.
+	Act(e game.Event) game.Action
+}
+
+// StrategyFunc adapts a function to the Strategy interface.
+type StrategyFunc func(e game.Event) game.Action
+
+// Act calls f(e).
+func (f StrategyFunc) Act(e game.Event) game.Action { return f(e) }
+
+// FirstChoice is a minimal strategy that drafts the first ingredient on
+// offer, designs a single dish from the first drafted ingredients each turn
+// and serves every customer. It never uses consumables.
+type FirstChoice struct {
+	phase  game.Phase
+	dishes int
+}
+
+// Act implements Strategy.
+func (s *FirstChoice) Act(e game.Event) game.Action {
+	switch ev := e.(type) {
+	case game.PhaseEvent:
+		s.phase = ev.Phase
+	case game.DraftOptionsEvent:
+		return game.DraftSelectionAction{Index: 0}
+	case game.DesignOptionsEvent:
+		n := len(ev.Drafted)
+		if n > dish.MaxIngredients {
+			n = dish.MaxIngredients
+		}
+		if n == 0 {
+			return game.FinishDesignAction{}
+		}
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		s.dishes++
+		return game.CreateDishAction{Name: fmt.Sprintf("Dish %d", s.dishes), Indices: indices}
+	case game.DishCreatedEvent:
+		return game.FinishDesignAction{}
+	case game.ServiceResultEvent:
+		return game.ContinueAction{}
+	case game.ActionRejectedEvent:
+		switch s.phase {
+		case game.PhaseDesign:
+			return game.FinishDesignAction{}
+		case game.PhaseService:
+			return game.ContinueAction{}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package ingredient
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rarity is the rarity tier of an ingredient. The zero value means the
+// tier was not specified and is treated as Common.
+type Rarity string
+
+const (
+	Common   Rarity = "Common"
+	Uncommon Rarity = "Uncommon"
+	Rare     Rarity = "Rare"
+)
+
+// Rarities is the registry of known rarity tiers from most to least common.
+var Rarities = []Rarity{Common, Uncommon, Rare}
+
+// Tier returns r, or Common if r is unspecified.
+func (r Rarity) Tier() Rarity {
+	if r == "" {
+		return Common
+	}
+	return r
+}
+
+// Valid reports whether r is unspecified or a registered tier.
+func (r Rarity) Valid() bool {
+	for _, known := range Rarities {
+		if known == r.Tier() {
+			return true
+		}
+	}
+	return false
+}
+
+// Flavor is the flavor profile of an ingredient or dish. Each component is a
+// non-negative intensity.
+type Flavor struct {
+	Salty int `yaml:"salty"`
+	Sweet int `yaml:"sweet"`
+	Sour  int `yaml:"sour"`
+	Umami int `yaml:"umami"`
+	Spicy int `yaml:"spicy"`
+}
+
+// Add returns the component-wise sum of f and o.
+func (f Flavor) Add(o Flavor) Flavor {
+	return Flavor{
+		Salty: f.Salty + o.Salty,
+		Sweet: f.Sweet + o.Sweet,
+		Sour:  f.Sour + o.Sour,
+		Umami: f.Umami + o.Umami,
+		Spicy: f.Spicy + o.Spicy,
+	}
+}
+
+// Total returns the sum of all flavor components.
+func (f Flavor) Total() int {
+	return f.Salty + f.Sweet + f.Sour + f.Umami + f.Spicy
+}
+
+// components returns the named flavor components in a fixed order.
+func (f Flavor) components() []struct {
+	name  string
+	value int
+} {
+	return []struct {
+		name  string
+		value int
+	}{
+		{"salty", f.Salty},
+		{"sweet", f.Sweet},
+		{"sour", f.Sour},
+		{"umami", f.Umami},
+		{"spicy", f.Spicy},
+	}
+}
+
+// Dominant returns the name of the strongest flavor component, or "" if the
+// profile is empty. Ties favor the earlier component.
+func (f Flavor) Dominant() string {
+	best, name := 0, ""
+	for _, c := range f.components() {
+		if c.value > best {
+			best, name = c.value, c.name
+		}
+	}
+	return name
+}
+
+// String lists the non-zero components, e.g. "salty 1, umami 2".
+func (f Flavor) String() string {
+	var parts []string
+	for _, c := range f.components() {
+		if c.value != 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", c.name, c.value))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// negative returns the name of the first negative component, or "".
+func (f Flavor) negative() string {
+	for _, c := range f.components() {
+		if c.value < 0 {
+			return c.name
+		}
+	}
+	return ""
+}
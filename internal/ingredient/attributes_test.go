@@ -0,0 +1,58 @@
+package ingredient_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/ingredient"
+)
+
+func TestLoadFromFileReadsAttributes(t *testing.T) {
+	data := `- name: Salmon
+  role: Protein
+  cost: 4
+  rarity: Rare
+  cuisine: Japanese
+  flavor:
+    salty: 1
+    umami: 3
+`
+	ings, err := ingredient.LoadFromFile(writeTemp(t, data))
+	require.NoError(t, err)
+	require.Len(t, ings, 1)
+	assert.Equal(t, ingredient.Ingredient{
+		Name:    "Salmon",
+		Role:    ingredient.Protein,
+		Cost:    4,
+		Rarity:  ingredient.Rare,
+		Cuisine: "Japanese",
+		Flavor:  ingredient.Flavor{Salty: 1, Umami: 3},
+	}, ings[0])
+	assert.Equal(t, "umami", ings[0].Flavor.Dominant())
+	assert.Equal(t, "salty 1, umami 3", ings[0].Flavor.String())
+}
+
+func TestValidateRejectsBadAttributes(t *testing.T) {
+	data := `- name: Salmon
+  role: Protein
+  cost: -1
+  rarity: Mythic
+  flavor:
+    sweet: -2
+`
+	_, problems, err := ingredient.Validate("bad.yaml", []byte(data))
+	require.NoError(t, err)
+	require.Len(t, problems, 3)
+	assert.Equal(t, "cost", problems[0].Field)
+	assert.Equal(t, 3, problems[0].Line)
+	assert.Equal(t, "rarity", problems[1].Field)
+	assert.Equal(t, "flavor.sweet", problems[2].Field)
+}
+
+func TestRarityTierDefaultsToCommon(t *testing.T) {
+	assert.Equal(t, ingredient.Common, ingredient.Rarity("").Tier())
+	assert.True(t, ingredient.Rarity("").Valid())
+	assert.False(t, ingredient.Rarity("Mythic").Valid())
+}
@@ -1,9 +1,8 @@
 package ingredient
 
 import (
+	"fmt"
 	"os"
-
-	"gopkg.in/yaml.v3"
 )
 
 // Role represents the role of an ingredient in a dish.
@@ -13,24 +12,66 @@ const (
 	Protein   Role = "Protein"
 	Carb      Role = "Carb"
 	Vegetable Role = "Vegetable"
+	Sauce     Role = "Sauce"
+	Dairy     Role = "Dairy"
+	Spice     Role = "Spice"
+	Fruit     Role = "Fruit"
+	Garnish   Role = "Garnish"
 )
 
-// Ingredient represents a single ingredient with a name and role.
+// Roles is the registry of known roles in display order. Draftable
+// ingredients are sorted by their role's position in this list.
+var Roles = []Role{Protein, Vegetable, Carb, Sauce, Dairy, Spice, Fruit, Garnish}
+
+// Valid reports whether r is a registered role.
+func (r Role) Valid() bool {
+	return r.Order() < len(Roles)
+}
+
+// Order returns the position of r in Roles. Unknown roles sort last.
+func (r Role) Order() int {
+	for i, known := range Roles {
+		if known == r {
+			return i
+		}
+	}
+	return len(Roles)
+}
+
+// ParseRole returns the registered role named s.
+func ParseRole(s string) (Role, error) {
+	r := Role(s)
+	if !r.Valid() {
+		return "", fmt.Errorf("unknown role %q (valid roles: %v)", s, Roles)
+	}
+	return r, nil
+}
+
+// Ingredient represents a single ingredient with a name and role. Cost,
+// rarity, cuisine and flavor are optional attributes.
 type Ingredient struct {
-	Name string `yaml:"name"`
-	Role Role   `yaml:"role"`
+	Name    string `yaml:"name"`
+	Role    Role   `yaml:"role"`
+	Cost    int    `yaml:"cost,omitempty"`
+	Rarity  Rarity `yaml:"rarity,omitempty"`
+	Cuisine string `yaml:"cuisine,omitempty"`
+	Flavor  Flavor `yaml:"flavor,omitempty"`
 }
 
 // LoadFromFile reads ingredients from a YAML file at the given path.
+// Content problems are reported together as a *ValidationError.
 func LoadFromFile(path string) ([]Ingredient, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	var ingredients []Ingredient
-	if err := yaml.Unmarshal(data, &ingredients); err != nil {
+	ingredients, problems, err := Validate(path, data)
+	if err != nil {
 		return nil, err
 	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
 	return ingredients, nil
 }
@@ -10,19 +10,23 @@ import (
 	"executive-chef/internal/ingredient"
 )
 
-func TestLoadFromFile(t *testing.T) {
-	data := `- name: Chicken
-  role: Protein
-- name: Rice
-  role: Carb
-`
+func writeTemp(t *testing.T, data string) string {
+	t.Helper()
 	tmpFile, err := os.CreateTemp(t.TempDir(), "ingredients-*.yaml")
 	require.NoError(t, err)
 	_, err = tmpFile.Write([]byte(data))
 	require.NoError(t, err)
 	require.NoError(t, tmpFile.Close())
+	return tmpFile.Name()
+}
 
-	ingredients, err := ingredient.LoadFromFile(tmpFile.Name())
+func TestLoadFromFile(t *testing.T) {
+	data := `- name: Chicken
+  role: Protein
+- name: Rice
+  role: Carb
+`
+	ingredients, err := ingredient.LoadFromFile(writeTemp(t, data))
 	require.NoError(t, err)
 	expected := []ingredient.Ingredient{
 		{Name: "Chicken", Role: ingredient.Protein},
@@ -30,3 +34,23 @@ func TestLoadFromFile(t *testing.T) {
 	}
 	assert.Equal(t, expected, ingredients)
 }
+
+func TestLoadFromFileRejectsUnknownRole(t *testing.T) {
+	data := `- name: Chicken
+  role: Protein
+- name: Mystery
+  role: Gizmo
+`
+	_, err := ingredient.LoadFromFile(writeTemp(t, data))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"Mystery"`)
+	assert.Contains(t, err.Error(), `unknown role "Gizmo"`)
+}
+
+func TestRoleOrderFollowsRegistry(t *testing.T) {
+	assert.Less(t, ingredient.Protein.Order(), ingredient.Vegetable.Order())
+	assert.Less(t, ingredient.Carb.Order(), ingredient.Garnish.Order())
+	assert.True(t, ingredient.Sauce.Valid())
+	assert.False(t, ingredient.Role("Gizmo").Valid())
+	assert.Equal(t, len(ingredient.Roles), ingredient.Role("Gizmo").Order())
+}
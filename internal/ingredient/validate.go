@@ -0,0 +1,150 @@
+package ingredient
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem describes a single issue found while validating ingredient content.
+// Line is 0 when the problem applies to the whole file.
+type Problem struct {
+	File    string
+	Line    int
+	Field   string
+	Message string
+}
+
+func (p Problem) Error() string {
+	loc := p.File
+	if p.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", p.File, p.Line)
+	}
+	if p.Field != "" {
+		return fmt.Sprintf("%s: %s: %s", loc, p.Field, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", loc, p.Message)
+}
+
+// ValidationError collects every problem found in an ingredient file.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Validate parses ingredient YAML read from file and checks it for
+// duplicate names, unknown roles and rarities, negative costs or flavors,
+// missing fields and an empty list. It
+// returns the parsed ingredients along with any problems found. A non-nil
+// error means the data was not valid YAML at all.
+func Validate(file string, data []byte) ([]Ingredient, []Problem, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, []Problem{{File: file, Message: "no ingredients defined"}}, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.SequenceNode {
+		return nil, []Problem{{File: file, Line: root.Line, Message: "expected a list of ingredients"}}, nil
+	}
+	if len(root.Content) == 0 {
+		return nil, []Problem{{File: file, Line: root.Line, Message: "no ingredients defined"}}, nil
+	}
+
+	var (
+		ingredients []Ingredient
+		problems    []Problem
+	)
+	firstSeen := make(map[string]int)
+	for _, item := range root.Content {
+		if item.Kind != yaml.MappingNode {
+			problems = append(problems, Problem{File: file, Line: item.Line, Message: "expected an ingredient mapping"})
+			continue
+		}
+		var ing Ingredient
+		if err := item.Decode(&ing); err != nil {
+			problems = append(problems, Problem{File: file, Line: item.Line, Message: err.Error()})
+			continue
+		}
+		nameLine, roleLine := fieldLine(item, "name"), fieldLine(item, "role")
+		valid := true
+		if strings.TrimSpace(ing.Name) == "" {
+			problems = append(problems, Problem{File: file, Line: lineOr(nameLine, item.Line), Field: "name", Message: "missing ingredient name"})
+			valid = false
+		} else if prev, ok := firstSeen[ing.Name]; ok {
+			problems = append(problems, Problem{
+				File: file, Line: nameLine, Field: "name",
+				Message: fmt.Sprintf("duplicate ingredient %q (first defined on line %d)", ing.Name, prev),
+			})
+			valid = false
+		} else {
+			firstSeen[ing.Name] = nameLine
+		}
+		if ing.Role == "" {
+			problems = append(problems, Problem{
+				File: file, Line: lineOr(roleLine, item.Line), Field: "role",
+				Message: fmt.Sprintf("ingredient %q is missing a role", ing.Name),
+			})
+			valid = false
+		} else if _, err := ParseRole(string(ing.Role)); err != nil {
+			problems = append(problems, Problem{
+				File: file, Line: roleLine, Field: "role",
+				Message: fmt.Sprintf("ingredient %q has %v", ing.Name, err),
+			})
+			valid = false
+		}
+		if ing.Cost < 0 {
+			problems = append(problems, Problem{
+				File: file, Line: fieldLine(item, "cost"), Field: "cost",
+				Message: fmt.Sprintf("ingredient %q has negative cost %d", ing.Name, ing.Cost),
+			})
+			valid = false
+		}
+		if !ing.Rarity.Valid() {
+			problems = append(problems, Problem{
+				File: file, Line: fieldLine(item, "rarity"), Field: "rarity",
+				Message: fmt.Sprintf("ingredient %q has unknown rarity %q (valid rarities: %v)", ing.Name, ing.Rarity, Rarities),
+			})
+			valid = false
+		}
+		if c := ing.Flavor.negative(); c != "" {
+			problems = append(problems, Problem{
+				File: file, Line: lineOr(fieldLine(item, "flavor"), item.Line), Field: "flavor." + c,
+				Message: fmt.Sprintf("ingredient %q has a negative %s flavor", ing.Name, c),
+			})
+			valid = false
+		}
+		if valid {
+			ingredients = append(ingredients, ing)
+		}
+	}
+	return ingredients, problems, nil
+}
+
+// fieldLine returns the line of the value for key in a mapping node, or 0 if
+// the key is absent.
+func fieldLine(m *yaml.Node, key string) int {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1].Line
+		}
+	}
+	return 0
+}
+
+func lineOr(line, fallback int) int {
+	if line > 0 {
+		return line
+	}
+	return fallback
+}
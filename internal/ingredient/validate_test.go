@@ -0,0 +1,57 @@
+package ingredient_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/ingredient"
+)
+
+func TestValidateReportsLineAndField(t *testing.T) {
+	data := `- name: Chicken
+  role: Protein
+- name: Chicken
+  role: Protein
+- role: Carb
+- name: Rice
+- name: Mystery
+  role: Gizmo
+`
+	ings, problems, err := ingredient.Validate("pantry.yaml", []byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []ingredient.Ingredient{{Name: "Chicken", Role: ingredient.Protein}}, ings)
+	require.Len(t, problems, 4)
+
+	assert.Equal(t, 3, problems[0].Line)
+	assert.Equal(t, "name", problems[0].Field)
+	assert.Contains(t, problems[0].Message, "duplicate")
+	assert.Equal(t, "pantry.yaml:3: name: duplicate ingredient \"Chicken\" (first defined on line 1)", problems[0].Error())
+
+	assert.Equal(t, 5, problems[1].Line)
+	assert.Equal(t, "name", problems[1].Field)
+
+	assert.Equal(t, 6, problems[2].Line)
+	assert.Equal(t, "role", problems[2].Field)
+
+	assert.Equal(t, 8, problems[3].Line)
+	assert.Contains(t, problems[3].Message, `unknown role "Gizmo"`)
+}
+
+func TestValidateEmptyList(t *testing.T) {
+	for _, data := range []string{"", "[]\n"} {
+		_, problems, err := ingredient.Validate("empty.yaml", []byte(data))
+		require.NoError(t, err)
+		require.Len(t, problems, 1)
+		assert.Contains(t, problems[0].Message, "no ingredients")
+	}
+}
+
+func TestLoadFromFileReturnsValidationError(t *testing.T) {
+	_, err := ingredient.LoadFromFile(writeTemp(t, "- name: Rice\n"))
+	var verr *ingredient.ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.Len(t, verr.Problems, 1)
+}
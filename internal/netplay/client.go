@@ -0,0 +1,96 @@
+package netplay
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+
+	"executive-chef/internal/game"
+)
+
+// maxLine is the longest message a client accepts from the server.
+const maxLine = 1 << 20
+
+// Client is a connection to a game hosted by a Server.
+type Client struct {
+	// Player is the client's player ID and Players the size of the table.
+	Player  int
+	Players int
+	// Events delivers the events visible to the client's player and is
+	// closed when the server ends the connection.
+	Events <-chan game.Event
+	// Actions sends actions to the server on the player's behalf.
+	Actions chan<- game.Action
+
+	conn net.Conn
+}
+
+// Dial connects to the server at addr and waits to be seated.
+func Dial(addr string) (*Client, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	sc := bufio.NewScanner(conn)
+	sc.Buffer(nil, maxLine)
+	if !sc.Scan() {
+		conn.Close()
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("netplay: server closed the connection")
+	}
+	e, err := game.UnmarshalEvent(sc.Bytes())
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	var welcome WelcomeEvent
+	switch e := e.(type) {
+	case WelcomeEvent:
+		welcome = e
+	case RefusedEvent:
+		conn.Close()
+		return nil, fmt.Errorf("netplay: refused: %s", e.Reason)
+	default:
+		conn.Close()
+		return nil, fmt.Errorf("netplay: expected a welcome, got %s", e.EventType())
+	}
+
+	events := make(chan game.Event)
+	actions := make(chan game.Action)
+	c := &Client{Player: welcome.Player, Players: welcome.Players, Events: events, Actions: actions, conn: conn}
+	go func() {
+		defer close(events)
+		events <- welcome
+		for sc.Scan() {
+			e, err := game.UnmarshalEvent(sc.Bytes())
+			if err != nil {
+				continue
+			}
+			events <- e
+		}
+	}()
+	go func() {
+		for a := range actions {
+			data, err := game.MarshalAction(a)
+			if err != nil {
+				continue
+			}
+			if _, err := conn.Write(append(data, '\n')); err != nil {
+				// Keep accepting actions so senders never block on
+				// a dead connection.
+				for range actions {
+				}
+				return
+			}
+		}
+	}()
+	return c, nil
+}
+
+// Close ends the connection to the server.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,52 @@
+// Package netplay hosts games over TCP and joins them from a remote client.
+// Messages are JSON lines encoded with the game codec: the server sends
+// events and the client sends actions.
+package netplay
+
+import (
+	"fmt"
+
+	"executive-chef/internal/game"
+)
+
+func init() {
+	game.RegisterEvent(WelcomeEvent{})
+	game.RegisterEvent(RefusedEvent{})
+	game.RegisterEvent(PlayerLeftEvent{})
+}
+
+// WelcomeEvent is the first message a seated client receives. Player is the
+// client's player ID and Players the number of seats at the table.
+type WelcomeEvent struct {
+	Player  int
+	Players int
+}
+
+func (e WelcomeEvent) EventType() string { return "welcome" }
+func (e WelcomeEvent) PlayerID() int     { return e.Player }
+
+// String describes the event for the game log.
+func (e WelcomeEvent) String() string {
+	return fmt.Sprintf("Seated as player %d of %d", e.Player, e.Players)
+}
+
+// RefusedEvent is sent to a client that cannot be seated before its
+// connection is closed.
+type RefusedEvent struct {
+	Reason string
+}
+
+func (e RefusedEvent) EventType() string { return "refused" }
+
+// PlayerLeftEvent announces that a player disconnected. A substitute bot
+// plays their seat for the rest of the game.
+type PlayerLeftEvent struct {
+	Player int
+}
+
+func (e PlayerLeftEvent) EventType() string { return "player_left" }
+
+// String describes the event for the game log.
+func (e PlayerLeftEvent) String() string {
+	return fmt.Sprintf("Player %d left; a bot takes their seat", e.Player)
+}
@@ -0,0 +1,140 @@
+package netplay_test
+
+import (
+	"bufio"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/netplay"
+	"executive-chef/internal/player"
+)
+
+// host starts a server for a game with the given number of players and
+// returns its address and a channel receiving Serve's result.
+func host(t *testing.T, players int) (string, <-chan error) {
+	t.Helper()
+	all := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein},
+		{Name: "Rice", Role: ingredient.Carb},
+		{Name: "Broccoli", Role: ingredient.Vegetable},
+	}
+	seats := make([]*player.Player, players)
+	for i := range seats {
+		seats[i] = player.New()
+	}
+	g := game.NewMultiplayer(deck.New(all), customer.NewDeck(all), seats, nil, nil)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	done := make(chan error, 1)
+	go func() {
+		s := &netplay.Server{}
+		done <- s.Serve(l, g)
+	}()
+	return l.Addr().String(), done
+}
+
+// play answers every event with s until the server closes the connection,
+// returning the events received.
+func play(c *netplay.Client, s headless.Strategy) []game.Event {
+	var seen []game.Event
+	for e := range c.Events {
+		seen = append(seen, e)
+		if a := s.Act(e); a != nil {
+			c.Actions <- a
+		}
+	}
+	return seen
+}
+
+func TestServerPlaysGameWithEveryClient(t *testing.T) {
+	addr, done := host(t, 2)
+	clients := make([]*netplay.Client, 2)
+	for i := range clients {
+		c, err := netplay.Dial(addr)
+		require.NoError(t, err)
+		defer c.Close()
+		assert.Equal(t, i, c.Player)
+		assert.Equal(t, 2, c.Players)
+		clients[i] = c
+	}
+
+	results := make(chan []game.Event, 2)
+	for _, c := range clients {
+		go func(c *netplay.Client) { results <- play(c, &headless.FirstChoice{}) }(c)
+	}
+	for range clients {
+		seen := <-results
+		require.NotEmpty(t, seen)
+		id := seen[0].(netplay.WelcomeEvent).Player
+		var over int
+		for _, e := range seen {
+			assert.True(t, game.VisibleTo(e, id), "player %d received %#v", id, e)
+			if _, ok := e.(game.GameOverEvent); ok {
+				over++
+			}
+		}
+		assert.Equal(t, 1, over)
+	}
+	require.NoError(t, <-done)
+}
+
+func TestServerRefusesClientsBeyondTheTable(t *testing.T) {
+	addr, done := host(t, 1)
+	c, err := netplay.Dial(addr)
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = netplay.Dial(addr)
+	assert.EqualError(t, err, "netplay: refused: the table is full")
+
+	play(c, &headless.FirstChoice{})
+	require.NoError(t, <-done)
+}
+
+func TestServerReplacesDisconnectedPlayers(t *testing.T) {
+	addr, done := host(t, 2)
+	stay, err := netplay.Dial(addr)
+	require.NoError(t, err)
+	defer stay.Close()
+	leave, err := netplay.Dial(addr)
+	require.NoError(t, err)
+	require.NoError(t, leave.Close())
+
+	seen := play(stay, &headless.FirstChoice{})
+	assert.Contains(t, seen, game.Event(netplay.PlayerLeftEvent{Player: 1}))
+	assert.IsType(t, game.GameOverEvent{}, seen[len(seen)-1])
+	require.NoError(t, <-done)
+}
+
+func TestServerRejectsInvalidMessages(t *testing.T) {
+	addr, done := host(t, 1)
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("not json\n"))
+	require.NoError(t, err)
+
+	sc := bufio.NewScanner(conn)
+	sc.Buffer(nil, 1<<20)
+	var rejected game.ActionRejectedEvent
+	for sc.Scan() {
+		e, err := game.UnmarshalEvent(sc.Bytes())
+		require.NoError(t, err)
+		if r, ok := e.(game.ActionRejectedEvent); ok {
+			rejected = r
+			break
+		}
+	}
+	assert.Nil(t, rejected.Action)
+	assert.Contains(t, rejected.Reason, "invalid message")
+
+	conn.Close()
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,219 @@
+package netplay
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+
+	"executive-chef/internal/bus"
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+)
+
+// outboxSize is the number of events at the table queued for a client
+// before it is considered too slow and disconnected.
+const outboxSize = 256
+
+// Server hosts a single game over TCP.
+type Server struct {
+	// Substitute returns the strategy that takes over the seat of a
+	// player who disconnects. Nil uses headless.FirstChoice.
+	Substitute func() headless.Strategy
+}
+
+// seat is a player's place at the table, held by a client until it
+// disconnects and by a substitute strategy afterwards. The client receives
+// the table's events through its subscription to the server's bus.
+type seat struct {
+	id   int
+	conn net.Conn
+	sub  *bus.Subscription
+	bot  headless.Strategy
+	// history holds the events sent to the client, so a substitute can
+	// catch up, and since the length it had when the client last acted.
+	history []game.Event
+	since   int
+}
+
+// message is something a client's reader received: an action, a decoding
+// error, or the end of the connection.
+type message struct {
+	seat   int
+	action game.Action
+	err    error
+	closed bool
+}
+
+// Serve seats one client per player in g as connections arrive on l, plays
+// the game and returns once every player has received their
+// GameOverEvent. Clients receive the events visible to their player, and
+// their actions are taken on their player's behalf whatever player ID they
+// carry. Each client subscribes to the game's events on a bus under the
+// Disconnect policy, so a client that falls outboxSize events behind is
+// disconnected and replaced rather than holding up the table. Connections
+// beyond the table's size are refused. Serve closes l before returning.
+func (s *Server) Serve(l net.Listener, g *game.Game) error {
+	defer l.Close()
+	n := len(g.Players)
+	if n == 0 {
+		return errors.New("netplay: game has no players")
+	}
+	incoming := make(chan message)
+	done := make(chan struct{})
+	defer close(done)
+
+	b := bus.New()
+	defer b.Close()
+	seats := make([]*seat, n)
+	var pending []game.Action
+	for i := range seats {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		st := &seat{id: i, conn: conn, sub: b.Subscribe(outboxSize, bus.Disconnect)}
+		seats[i] = st
+		pending = s.publish(b, seats, WelcomeEvent{Player: i, Players: n}, pending)
+		go write(st.id, conn, st.sub.C)
+		go read(st.id, conn, incoming, done)
+	}
+	go refuse(l)
+
+	events := make(chan game.Event)
+	actions := make(chan game.Action)
+	g.Events = events
+	g.Actions = actions
+	go g.Play()
+
+	over := 0
+	for {
+		var out chan<- game.Action
+		var next game.Action
+		if len(pending) > 0 {
+			out = actions
+			next = pending[0]
+		}
+		select {
+		case e := <-events:
+			pending = s.publish(b, seats, e, pending)
+			if _, ok := e.(game.GameOverEvent); ok {
+				if over++; over == n {
+					return nil
+				}
+			}
+		case m := <-incoming:
+			st := seats[m.seat]
+			switch {
+			case st.bot != nil:
+				// Late messages from a client that has been replaced.
+			case m.closed:
+				pending = s.replace(b, seats, st, pending)
+			case m.err != nil:
+				pending = s.publish(b, seats, game.ActionRejectedEvent{Player: st.id, Reason: fmt.Sprintf("invalid message: %v", m.err)}, pending)
+			default:
+				st.since = len(st.history)
+				pending = append(pending, game.ForPlayer(m.action, st.id))
+			}
+		case out <- next:
+			pending = pending[1:]
+		}
+	}
+}
+
+// publish sends e to the seated clients through the bus and hands it to
+// the substitutes of the players it is visible to, queuing any action they
+// take.
+func (s *Server) publish(b *bus.Bus, seats []*seat, e game.Event, pending []game.Action) []game.Action {
+	b.Publish(e)
+	for _, st := range seats {
+		if st == nil || !game.VisibleTo(e, st.id) {
+			continue
+		}
+		if st.bot == nil {
+			st.history = append(st.history, e)
+		} else if a := st.bot.Act(e); a != nil {
+			pending = append(pending, game.ForPlayer(a, st.id))
+		}
+	}
+	return pending
+}
+
+// replace hands a disconnected client's seat to a substitute, which replays
+// the events the client saw and answers those that arrived after the
+// client's last action, and tells the other players.
+func (s *Server) replace(b *bus.Bus, seats []*seat, st *seat, pending []game.Action) []game.Action {
+	st.sub.Close()
+	st.conn.Close()
+	bot := headless.Strategy(&headless.FirstChoice{})
+	if s.Substitute != nil {
+		bot = s.Substitute()
+	}
+	for i, e := range st.history {
+		if a := bot.Act(e); i >= st.since && a != nil {
+			pending = append(pending, game.ForPlayer(a, st.id))
+		}
+	}
+	st.bot = bot
+	st.history = nil
+	return s.publish(b, seats, PlayerLeftEvent{Player: st.id}, pending)
+}
+
+// write sends the events queued in out that are visible to the player with
+// the given ID to conn as JSON lines, closing the connection once out is
+// closed or a write fails.
+func write(id int, conn net.Conn, out <-chan game.Event) {
+	defer conn.Close()
+	w := bufio.NewWriter(conn)
+	for e := range out {
+		if game.VisibleTo(e, id) {
+			if data, err := game.MarshalEvent(e); err == nil {
+				w.Write(data)
+				w.WriteByte('\n')
+			}
+		}
+		if len(out) == 0 {
+			if err := w.Flush(); err != nil {
+				for range out {
+				}
+				return
+			}
+		}
+	}
+	w.Flush()
+}
+
+// read decodes the actions arriving on conn and passes them to the server
+// until the connection ends.
+func read(id int, conn net.Conn, incoming chan<- message, done <-chan struct{}) {
+	send := func(m message) bool {
+		select {
+		case incoming <- m:
+			return true
+		case <-done:
+			return false
+		}
+	}
+	sc := bufio.NewScanner(conn)
+	for sc.Scan() {
+		a, err := game.UnmarshalAction(sc.Bytes())
+		if !send(message{seat: id, action: a, err: err}) {
+			return
+		}
+	}
+	send(message{seat: id, closed: true})
+}
+
+// refuse turns away every further connection until l is closed.
+func refuse(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		if data, err := game.MarshalEvent(RefusedEvent{Reason: "the table is full"}); err == nil {
+			conn.Write(append(data, '\n'))
+		}
+		conn.Close()
+	}
+}
@@ -0,0 +1,100 @@
+package pairing
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"executive-chef/internal/ingredient"
+)
+
+// Affinity describes how well two ingredients go together.
+type Affinity string
+
+const (
+	Great   Affinity = "great"
+	Good    Affinity = "good"
+	Neutral Affinity = "neutral"
+	Clash   Affinity = "clash"
+)
+
+// affinityScores maps each affinity to the synergy it contributes to a dish.
+var affinityScores = map[Affinity]int{
+	Great:   3,
+	Good:    2,
+	Neutral: 0,
+	Clash:   -2,
+}
+
+// Score returns the synergy contributed by a pair with this affinity.
+func (a Affinity) Score() int {
+	return affinityScores[a]
+}
+
+// Pairing records the affinity between two ingredients, by name.
+type Pairing struct {
+	Ingredients [2]string `yaml:"ingredients"`
+	Affinity    Affinity  `yaml:"affinity"`
+}
+
+// Table holds the known pairings. A nil table has no pairings.
+type Table struct {
+	Pairings []Pairing
+}
+
+// LoadFromFile reads pairings from a YAML file at the given path. Every
+// pairing must name ingredients from known and use a recognized affinity.
+func LoadFromFile(path string, known []ingredient.Ingredient) (*Table, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pairings []Pairing
+	if err := yaml.Unmarshal(data, &pairings); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	names := make(map[string]bool, len(known))
+	for _, ing := range known {
+		names[ing.Name] = true
+	}
+	for i, p := range pairings {
+		for _, name := range p.Ingredients {
+			if !names[name] {
+				return nil, fmt.Errorf("%s: pairing %d: unknown ingredient %q", path, i+1, name)
+			}
+		}
+		if _, ok := affinityScores[p.Affinity]; !ok {
+			return nil, fmt.Errorf("%s: pairing %d: unknown affinity %q", path, i+1, p.Affinity)
+		}
+	}
+	return &Table{Pairings: pairings}, nil
+}
+
+// Lookup returns the affinity between two ingredients in either order.
+// The second return value is false if the pair is not listed.
+func (t *Table) Lookup(a, b string) (Affinity, bool) {
+	if t == nil {
+		return "", false
+	}
+	for _, p := range t.Pairings {
+		if (p.Ingredients[0] == a && p.Ingredients[1] == b) || (p.Ingredients[0] == b && p.Ingredients[1] == a) {
+			return p.Affinity, true
+		}
+	}
+	return "", false
+}
+
+// Synergy sums the affinity scores of every distinct pair of ingredients.
+// Unlisted pairs contribute nothing.
+func (t *Table) Synergy(ings []ingredient.Ingredient) int {
+	total := 0
+	for i := range ings {
+		for j := i + 1; j < len(ings); j++ {
+			if a, ok := t.Lookup(ings[i].Name, ings[j].Name); ok {
+				total += a.Score()
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,56 @@
+package pairing_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/pairing"
+)
+
+var (
+	salmon  = ingredient.Ingredient{Name: "Salmon", Role: ingredient.Protein}
+	rice    = ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	spinach = ingredient.Ingredient{Name: "Spinach", Role: ingredient.Vegetable}
+)
+
+func writeTemp(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pairings.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	return path
+}
+
+func TestLoadFromFileAndSynergy(t *testing.T) {
+	data := `- ingredients: [Salmon, Rice]
+  affinity: good
+- ingredients: [Spinach, Salmon]
+  affinity: clash
+`
+	table, err := pairing.LoadFromFile(writeTemp(t, data), []ingredient.Ingredient{salmon, rice, spinach})
+	require.NoError(t, err)
+
+	a, ok := table.Lookup("Rice", "Salmon")
+	assert.True(t, ok)
+	assert.Equal(t, pairing.Good, a)
+	assert.Equal(t, 2, table.Synergy([]ingredient.Ingredient{salmon, rice}))
+	assert.Equal(t, 0, table.Synergy([]ingredient.Ingredient{salmon, rice, spinach}))
+	assert.Equal(t, 0, table.Synergy([]ingredient.Ingredient{rice, spinach}))
+}
+
+func TestLoadFromFileRejectsUnknownNamesAndAffinities(t *testing.T) {
+	known := []ingredient.Ingredient{salmon, rice}
+	_, err := pairing.LoadFromFile(writeTemp(t, "- ingredients: [Salmon, Tofu]\n  affinity: good\n"), known)
+	assert.ErrorContains(t, err, `unknown ingredient "Tofu"`)
+	_, err = pairing.LoadFromFile(writeTemp(t, "- ingredients: [Salmon, Rice]\n  affinity: meh\n"), known)
+	assert.ErrorContains(t, err, `unknown affinity "meh"`)
+}
+
+func TestNilTableHasNoSynergy(t *testing.T) {
+	var table *pairing.Table
+	assert.Equal(t, 0, table.Synergy([]ingredient.Ingredient{salmon, rice}))
+}
@@ -1,15 +1,22 @@
 package player
 
 import (
+	"slices"
+
+	"executive-chef/internal/consumable"
 	"executive-chef/internal/dish"
 	"executive-chef/internal/ingredient"
 )
 
+// MaxConsumables is the maximum number of consumables a player may hold.
+const MaxConsumables = 3
+
 // Player represents a game participant who drafts ingredients and designs dishes.
 type Player struct {
-	Drafted []ingredient.Ingredient
-	Dishes  []dish.Dish
-	Money   int
+	Drafted     []ingredient.Ingredient
+	Dishes      []dish.Dish
+	Money       int
+	Consumables []consumable.Consumable
 }
 
 // New creates a player with empty drafted and dish lists.
@@ -17,6 +24,16 @@ func New() *Player {
 	return &Player{Drafted: []ingredient.Ingredient{}, Dishes: []dish.Dish{}, Money: 0}
 }
 
+// Clone returns a copy of the player that shares nothing they can change.
+func (p *Player) Clone() *Player {
+	return &Player{
+		Drafted:     slices.Clone(p.Drafted),
+		Dishes:      slices.Clone(p.Dishes),
+		Money:       p.Money,
+		Consumables: slices.Clone(p.Consumables),
+	}
+}
+
 // Add adds an ingredient to the player's drafted list.
 func (p *Player) Add(ing ingredient.Ingredient) {
 	p.Drafted = append(p.Drafted, ing)
@@ -43,6 +60,37 @@ func (p *Player) AddMoney(amount int) {
 	p.Money += amount
 }
 
+// AddConsumable adds a consumable to the player's hand.
+// It returns false if the hand already holds MaxConsumables cards.
+func (p *Player) AddConsumable(c consumable.Consumable) bool {
+	if len(p.Consumables) >= MaxConsumables {
+		return false
+	}
+	p.Consumables = append(p.Consumables, c)
+	return true
+}
+
+// RemoveConsumable removes and returns the consumable at the given index.
+// The second return value is false if the index is out of range.
+func (p *Player) RemoveConsumable(i int) (consumable.Consumable, bool) {
+	if i < 0 || i >= len(p.Consumables) {
+		return consumable.Consumable{}, false
+	}
+	c := p.Consumables[i]
+	p.Consumables = append(p.Consumables[:i], p.Consumables[i+1:]...)
+	return c, true
+}
+
+// ReplaceDrafted swaps the drafted ingredient at the given index.
+// It returns false if the index is out of range.
+func (p *Player) ReplaceDrafted(i int, ing ingredient.Ingredient) bool {
+	if i < 0 || i >= len(p.Drafted) {
+		return false
+	}
+	p.Drafted[i] = ing
+	return true
+}
+
 // ResetTurn clears drafted ingredients for a new turn while keeping dishes.
 func (p *Player) ResetTurn() {
 	p.Drafted = nil
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"executive-chef/internal/consumable"
 	"executive-chef/internal/dish"
 	"executive-chef/internal/ingredient"
 	"executive-chef/internal/player"
@@ -41,3 +42,19 @@ func TestPlayerLifecycle(t *testing.T) {
 	p.ResetTurn()
 	assert.Empty(t, p.Drafted)
 }
+
+func TestPlayerConsumableLimit(t *testing.T) {
+	p := player.New()
+	for i := 0; i < player.MaxConsumables; i++ {
+		assert.True(t, p.AddConsumable(consumable.Consumable{Kind: consumable.Peek}))
+	}
+	assert.False(t, p.AddConsumable(consumable.Consumable{Kind: consumable.Reroll}))
+	assert.Len(t, p.Consumables, player.MaxConsumables)
+
+	c, ok := p.RemoveConsumable(0)
+	assert.True(t, ok)
+	assert.Equal(t, consumable.Peek, c.Kind)
+	assert.Len(t, p.Consumables, player.MaxConsumables-1)
+	_, ok = p.RemoveConsumable(5)
+	assert.False(t, ok)
+}
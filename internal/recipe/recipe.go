@@ -0,0 +1,174 @@
+package recipe
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"executive-chef/internal/dish"
+	"executive-chef/internal/ingredient"
+)
+
+// Recipe is a named combination of ingredients worth a bonus when served.
+type Recipe struct {
+	Name        string   `yaml:"name"`
+	Ingredients []string `yaml:"ingredients"`
+	Bonus       int      `yaml:"bonus"`
+}
+
+// Matches reports whether ings contains exactly the recipe's ingredients,
+// in any order.
+func (r Recipe) Matches(ings []ingredient.Ingredient) bool {
+	if len(ings) != len(r.Ingredients) {
+		return false
+	}
+	want := make(map[string]bool, len(r.Ingredients))
+	for _, name := range r.Ingredients {
+		want[name] = true
+	}
+	for _, ing := range ings {
+		if !want[ing.Name] {
+			return false
+		}
+		delete(want, ing.Name)
+	}
+	return len(want) == 0
+}
+
+// Entry is the player-visible view of a recipe. Name and Ingredients are
+// empty until the recipe has been discovered.
+type Entry struct {
+	Name        string
+	Ingredients []string
+	Size        int
+	Bonus       int
+	Discovered  bool
+}
+
+// Book holds every recipe and tracks which have been discovered.
+// A nil book has no recipes.
+type Book struct {
+	Recipes    []Recipe
+	Discovered map[string]bool
+}
+
+// NewBook creates a book with no recipes discovered.
+func NewBook(recipes []Recipe) *Book {
+	return &Book{Recipes: recipes, Discovered: make(map[string]bool)}
+}
+
+// Clone returns a copy of the book whose discoveries are tracked apart from
+// b's. The recipes themselves are shared.
+func (b *Book) Clone() *Book {
+	if b == nil {
+		return nil
+	}
+	return &Book{Recipes: b.Recipes, Discovered: maps.Clone(b.Discovered)}
+}
+
+// LoadFromFile reads recipes from a YAML file at the given path. Every
+// recipe must have a unique name and between one and dish.MaxIngredients
+// distinct ingredients from known.
+func LoadFromFile(path string, known []ingredient.Ingredient) (*Book, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var recipes []Recipe
+	if err := yaml.Unmarshal(data, &recipes); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	names := make(map[string]bool, len(known))
+	for _, ing := range known {
+		names[ing.Name] = true
+	}
+	seen := make(map[string]bool)
+	for i, r := range recipes {
+		if r.Name == "" {
+			return nil, fmt.Errorf("%s: recipe %d: missing name", path, i+1)
+		}
+		if seen[r.Name] {
+			return nil, fmt.Errorf("%s: recipe %d: duplicate recipe %q", path, i+1, r.Name)
+		}
+		seen[r.Name] = true
+		if len(r.Ingredients) == 0 || len(r.Ingredients) > dish.MaxIngredients {
+			return nil, fmt.Errorf("%s: recipe %q must have 1 to %d ingredients", path, r.Name, dish.MaxIngredients)
+		}
+		used := make(map[string]bool)
+		for _, name := range r.Ingredients {
+			if !names[name] {
+				return nil, fmt.Errorf("%s: recipe %q: unknown ingredient %q", path, r.Name, name)
+			}
+			if used[name] {
+				return nil, fmt.Errorf("%s: recipe %q lists %q twice", path, r.Name, name)
+			}
+			used[name] = true
+		}
+	}
+	return NewBook(recipes), nil
+}
+
+// Match returns the recipe made by exactly the given ingredients.
+// The second return value is false if no recipe matches.
+func (b *Book) Match(ings []ingredient.Ingredient) (Recipe, bool) {
+	if b == nil {
+		return Recipe{}, false
+	}
+	for _, r := range b.Recipes {
+		if r.Matches(ings) {
+			return r, true
+		}
+	}
+	return Recipe{}, false
+}
+
+// Discover marks the named recipe as discovered and reports whether it was
+// newly discovered.
+func (b *Book) Discover(name string) bool {
+	if b.Discovered == nil {
+		b.Discovered = make(map[string]bool)
+	}
+	if b.Discovered[name] {
+		return false
+	}
+	b.Discovered[name] = true
+	return true
+}
+
+// Entries returns the player-visible view of every recipe in book order.
+func (b *Book) Entries() []Entry {
+	if b == nil {
+		return nil
+	}
+	entries := make([]Entry, len(b.Recipes))
+	for i, r := range b.Recipes {
+		entries[i] = Entry{Size: len(r.Ingredients), Bonus: r.Bonus}
+		if b.Discovered[r.Name] {
+			entries[i] = r.Entry()
+		}
+	}
+	return entries
+}
+
+// Index returns the position of the named recipe in the book, or -1.
+func (b *Book) Index(name string) int {
+	if b == nil {
+		return -1
+	}
+	for i, r := range b.Recipes {
+		if r.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Entry returns the discovered view of the recipe with sorted ingredients.
+func (r Recipe) Entry() Entry {
+	ings := append([]string(nil), r.Ingredients...)
+	sort.Strings(ings)
+	return Entry{Name: r.Name, Ingredients: ings, Size: len(ings), Bonus: r.Bonus, Discovered: true}
+}
@@ -0,0 +1,67 @@
+package recipe_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/recipe"
+)
+
+var (
+	beef   = ingredient.Ingredient{Name: "Beef", Role: ingredient.Protein}
+	potato = ingredient.Ingredient{Name: "Potato", Role: ingredient.Carb}
+	carrot = ingredient.Ingredient{Name: "Carrot", Role: ingredient.Vegetable}
+)
+
+func writeTemp(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "recipes.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	return path
+}
+
+func TestMatchIgnoresOrder(t *testing.T) {
+	book := recipe.NewBook([]recipe.Recipe{
+		{Name: "Pot Roast", Ingredients: []string{"Beef", "Potato", "Carrot"}, Bonus: 4},
+	})
+	r, ok := book.Match([]ingredient.Ingredient{carrot, beef, potato})
+	require.True(t, ok)
+	assert.Equal(t, "Pot Roast", r.Name)
+
+	_, ok = book.Match([]ingredient.Ingredient{beef, potato})
+	assert.False(t, ok)
+	_, ok = book.Match([]ingredient.Ingredient{beef, beef, potato})
+	assert.False(t, ok)
+}
+
+func TestEntriesHideUndiscoveredRecipes(t *testing.T) {
+	book := recipe.NewBook([]recipe.Recipe{
+		{Name: "Pot Roast", Ingredients: []string{"Potato", "Beef", "Carrot"}, Bonus: 4},
+		{Name: "Steak Frites", Ingredients: []string{"Beef", "Potato"}, Bonus: 2},
+	})
+	assert.True(t, book.Discover("Pot Roast"))
+	assert.False(t, book.Discover("Pot Roast"))
+
+	entries := book.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, recipe.Entry{Name: "Pot Roast", Ingredients: []string{"Beef", "Carrot", "Potato"}, Size: 3, Bonus: 4, Discovered: true}, entries[0])
+	assert.Equal(t, recipe.Entry{Size: 2, Bonus: 2}, entries[1])
+	assert.Equal(t, 1, book.Index("Steak Frites"))
+}
+
+func TestLoadFromFileValidatesRecipes(t *testing.T) {
+	known := []ingredient.Ingredient{beef, potato, carrot}
+	book, err := recipe.LoadFromFile(writeTemp(t, "- name: Pot Roast\n  ingredients: [Beef, Potato, Carrot]\n  bonus: 4\n"), known)
+	require.NoError(t, err)
+	assert.Len(t, book.Recipes, 1)
+
+	_, err = recipe.LoadFromFile(writeTemp(t, "- name: Tofu Bowl\n  ingredients: [Tofu]\n"), known)
+	assert.ErrorContains(t, err, `unknown ingredient "Tofu"`)
+	_, err = recipe.LoadFromFile(writeTemp(t, "- name: Stew\n  ingredients: [Beef, Potato, Carrot, Beef]\n"), known)
+	assert.ErrorContains(t, err, "1 to 3 ingredients")
+}
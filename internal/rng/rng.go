@@ -0,0 +1,46 @@
+// Package rng supplies the random number generators used to build and play
+// games. Seeded generators make games reproducible, while the shared
+// generator keeps the behaviour of the math/rand package-level functions.
+package rng
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+// New returns a generator seeded with seed. It must not be shared between
+// goroutines.
+func New(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// Global returns a generator drawing from the math/rand package-level source.
+// Apart from Read, its methods are safe for concurrent use.
+func Global() *rand.Rand {
+	return global
+}
+
+var global = rand.New(globalSource{})
+
+// globalSource forwards to the math/rand package-level functions.
+type globalSource struct{}
+
+func (globalSource) Int63() int64   { return rand.Int63() }
+func (globalSource) Uint64() uint64 { return rand.Uint64() }
+func (globalSource) Seed(int64)     {}
+
+// Source is a seeded source of random numbers held as a plain value.
+// Copying a Source forks it: the copy draws the numbers the original would
+// have drawn next, without disturbing the original.
+type Source struct {
+	pcg randv2.PCG
+}
+
+// NewSource returns a source seeded with seed.
+func NewSource(seed int64) Source {
+	return Source{pcg: *randv2.NewPCG(uint64(seed), 0)}
+}
+
+func (s *Source) Int63() int64    { return int64(s.pcg.Uint64() >> 1) }
+func (s *Source) Uint64() uint64  { return s.pcg.Uint64() }
+func (s *Source) Seed(seed int64) { s.pcg.Seed(uint64(seed), 0) }
@@ -0,0 +1,33 @@
+package rng_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"executive-chef/internal/rng"
+)
+
+func TestNewIsReproducible(t *testing.T) {
+	a, b := rng.New(42), rng.New(42)
+	assert.Equal(t, a.Perm(10), b.Perm(10))
+}
+
+func TestGlobalIsUsableConcurrently(t *testing.T) {
+	done := make(chan []int)
+	for i := 0; i < 4; i++ {
+		go func() { done <- rng.Global().Perm(5) }()
+	}
+	for i := 0; i < 4; i++ {
+		assert.ElementsMatch(t, []int{0, 1, 2, 3, 4}, <-done)
+	}
+}
+
+func TestSourceCopiesFork(t *testing.T) {
+	src := rng.NewSource(42)
+	rand.New(&src).Int63()
+	fork := src
+	a, b := rand.New(&src), rand.New(&fork)
+	assert.Equal(t, a.Perm(10), b.Perm(10))
+}
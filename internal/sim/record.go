@@ -0,0 +1,73 @@
+package sim
+
+import (
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+)
+
+// record wraps the strategy playing one game and tallies what happens.
+type record struct {
+	headless.Strategy
+
+	seed               int64
+	money, ante, turns int
+	turn               int
+
+	// offered counts the draft decisions in which each ingredient was on
+	// offer, and picked how many of those ended with it being drafted.
+	offered map[string]int
+	picked  map[string]int
+	// servedWith counts the served dishes containing each ingredient.
+	servedWith map[string]int
+
+	customers int
+	served    int
+	// income holds the money earned in each turn, indexed from turn 1.
+	income []int
+}
+
+func newRecord(s headless.Strategy) *record {
+	return &record{
+		Strategy:   s,
+		offered:    make(map[string]int),
+		picked:     make(map[string]int),
+		servedWith: make(map[string]int),
+	}
+}
+
+// Act tallies e and passes it on to the wrapped strategy.
+func (r *record) Act(e game.Event) game.Action {
+	switch ev := e.(type) {
+	case game.PhaseEvent:
+		r.turn = ev.Turn
+		for len(r.income) < ev.Turn {
+			r.income = append(r.income, 0)
+		}
+	case game.DraftOptionsEvent:
+		seen := make(map[string]bool)
+		for _, ing := range ev.Reveal {
+			if !seen[ing.Name] {
+				seen[ing.Name] = true
+				r.offered[ing.Name]++
+			}
+		}
+	case game.IngredientDraftedEvent:
+		r.picked[ev.Ingredient.Name]++
+	case game.ServiceResultEvent:
+		r.customers++
+		if ev.Dish != nil {
+			r.served++
+			seen := make(map[string]bool)
+			for _, ing := range ev.Dish.Ingredients {
+				if !seen[ing.Name] {
+					seen[ing.Name] = true
+					r.servedWith[ing.Name]++
+				}
+			}
+		}
+		if r.turn > 0 {
+			r.income[r.turn-1] += ev.Payment
+		}
+	}
+	return r.Strategy.Act(e)
+}
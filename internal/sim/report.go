@@ -0,0 +1,219 @@
+package sim
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+)
+
+// Report summarizes a batch of simulated games.
+type Report struct {
+	Games int
+	Seed  int64
+
+	// Money describes the money players ended their games with.
+	Money Distribution
+	// Antes counts the games that ended in each ante.
+	Antes map[int]int
+
+	// Customers is the number of customers who came to service, and
+	// Served how many of them were served a dish.
+	Customers int
+	Served    int
+
+	// Ingredients holds per-ingredient draft and service counts, sorted by
+	// name.
+	Ingredients []IngredientStats
+	// Income holds the money earned in each turn, starting with turn 1.
+	Income []TurnIncome
+	// Outcomes holds how each game ended, ordered by seed.
+	Outcomes []Outcome
+
+	money       []int
+	ingredients map[string]*IngredientStats
+}
+
+// Distribution summarizes a set of values.
+type Distribution struct {
+	Mean, StdDev  float64
+	Min, Max      int
+	P10, P50, P90 int
+}
+
+// IngredientStats counts how often an ingredient was offered, drafted and
+// served across all games.
+type IngredientStats struct {
+	Name string
+	// Offered is the number of draft decisions in which it was on offer.
+	Offered int
+	// Picked is the number of times it was drafted.
+	Picked int
+	// Served is the number of served dishes containing it.
+	Served int
+}
+
+// Outcome is how a single game ended.
+type Outcome struct {
+	Seed  int64
+	Ante  int
+	Turns int
+	Money int
+}
+
+// TurnIncome is the money earned in one turn by the games that reached it.
+type TurnIncome struct {
+	Turn  int
+	Games int
+	Total int
+}
+
+// Mean returns the average income of the games that reached the turn.
+func (t TurnIncome) Mean() float64 {
+	return ratio(t.Total, t.Games)
+}
+
+func newReport(cfg Config) *Report {
+	return &Report{
+		Games:       cfg.Games,
+		Seed:        cfg.Seed,
+		Antes:       make(map[int]int),
+		ingredients: make(map[string]*IngredientStats),
+	}
+}
+
+// add merges one game's record into the report.
+func (r *Report) add(rec *record) {
+	r.money = append(r.money, rec.money)
+	r.Outcomes = append(r.Outcomes, Outcome{Seed: rec.seed, Ante: rec.ante, Turns: rec.turns, Money: rec.money})
+	r.Antes[rec.ante]++
+	r.Customers += rec.customers
+	r.Served += rec.served
+	stats := func(name string) *IngredientStats {
+		s, ok := r.ingredients[name]
+		if !ok {
+			s = &IngredientStats{Name: name}
+			r.ingredients[name] = s
+		}
+		return s
+	}
+	for name, n := range rec.offered {
+		stats(name).Offered += n
+	}
+	for name, n := range rec.picked {
+		stats(name).Picked += n
+	}
+	for name, n := range rec.servedWith {
+		stats(name).Served += n
+	}
+	for i, income := range rec.income {
+		if i >= len(r.Income) {
+			r.Income = append(r.Income, TurnIncome{Turn: i + 1})
+		}
+		r.Income[i].Games++
+		r.Income[i].Total += income
+	}
+}
+
+// finish computes the summaries once every game has been added.
+func (r *Report) finish() {
+	r.Money = distribution(r.money)
+	r.Ingredients = r.Ingredients[:0]
+	for _, s := range r.ingredients {
+		r.Ingredients = append(r.Ingredients, *s)
+	}
+	sort.Slice(r.Ingredients, func(i, j int) bool { return r.Ingredients[i].Name < r.Ingredients[j].Name })
+	sort.Slice(r.Outcomes, func(i, j int) bool { return r.Outcomes[i].Seed < r.Outcomes[j].Seed })
+}
+
+// UnservedRate returns the share of customers who were not served a dish.
+func (r *Report) UnservedRate() float64 {
+	return ratio(r.Customers-r.Served, r.Customers)
+}
+
+// PickRate returns the share of draft decisions offering the ingredient in
+// which it was drafted.
+func (s IngredientStats) PickRate() float64 {
+	return ratio(s.Picked, s.Offered)
+}
+
+// ServeRate returns the share of served dishes, out of served, that
+// contained the ingredient.
+func (s IngredientStats) ServeRate(served int) float64 {
+	return ratio(s.Served, served)
+}
+
+// Write prints the report as text.
+func (r *Report) Write(w io.Writer) error {
+	fmt.Fprintf(w, "Games: %d (seeds %d-%d)\n", r.Games, r.Seed, r.Seed+int64(r.Games)-1)
+	m := r.Money
+	fmt.Fprintf(w, "Money: mean $%.1f (sd %.1f), min $%d, p10 $%d, median $%d, p90 $%d, max $%d\n",
+		m.Mean, m.StdDev, m.Min, m.P10, m.P50, m.P90, m.Max)
+	antes := make([]int, 0, len(r.Antes))
+	for a := range r.Antes {
+		antes = append(antes, a)
+	}
+	sort.Ints(antes)
+	fmt.Fprint(w, "Final ante:")
+	for _, a := range antes {
+		fmt.Fprintf(w, " %d: %.1f%%", a, 100*ratio(r.Antes[a], r.Games))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Unserved customers: %d of %d (%.1f%%)\n\n", r.Customers-r.Served, r.Customers, 100*r.UnservedRate())
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Ingredient\tOffered\tPick rate\tServe rate")
+	for _, s := range r.Ingredients {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f%%\t%.1f%%\n", s.Name, s.Offered, 100*s.PickRate(), 100*s.ServeRate(r.Served))
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Turn\tGames\tMean income")
+	for _, t := range r.Income {
+		fmt.Fprintf(tw, "%d\t%d\t$%.1f\n", t.Turn, t.Games, t.Mean())
+	}
+	return tw.Flush()
+}
+
+// distribution summarizes values, sorting them in place.
+func distribution(values []int) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sort.Ints(values)
+	sum := 0
+	for _, v := range values {
+		sum += v
+	}
+	d := Distribution{
+		Mean: float64(sum) / float64(len(values)),
+		Min:  values[0],
+		Max:  values[len(values)-1],
+		P10:  percentile(values, 10),
+		P50:  percentile(values, 50),
+		P90:  percentile(values, 90),
+	}
+	var sq float64
+	for _, v := range values {
+		diff := float64(v) - d.Mean
+		sq += diff * diff
+	}
+	d.StdDev = math.Sqrt(sq / float64(len(values)))
+	return d
+}
+
+// percentile returns the nearest-rank percentile p of sorted values.
+func percentile(sorted []int, p int) int {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
@@ -0,0 +1,122 @@
+// Package sim plays batches of seeded headless games in parallel and
+// summarizes how they went, as a basis for balancing game content.
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+	"executive-chef/internal/rng"
+)
+
+// Config describes a batch of simulated games.
+type Config struct {
+	// Games is the number of games to play.
+	Games int
+	// Seed is the seed of the first game; game i is played with Seed+i.
+	Seed int64
+	// Workers is the number of games played at once. Zero or less uses
+	// one worker per CPU.
+	Workers int
+	// NewGame builds a game drawing every random choice from r.
+	NewGame func(r *rand.Rand) (*game.Game, error)
+	// NewStrategy returns a fresh strategy to play one game.
+	NewStrategy func() headless.Strategy
+}
+
+// Run plays the configured games and reports on them. The report depends only
+// on the configuration, not on the number of workers. Run stops at the first
+// game that fails.
+func Run(cfg Config) (*Report, error) {
+	if cfg.Games <= 0 {
+		return nil, errors.New("sim: no games to play")
+	}
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > cfg.Games {
+		workers = cfg.Games
+	}
+
+	seeds := make(chan int64)
+	results := make(chan *record)
+	errs := make(chan error, workers)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seed := range seeds {
+				rec, err := play(cfg, seed)
+				if err != nil {
+					errs <- fmt.Errorf("sim: game with seed %d: %w", seed, err)
+					return
+				}
+				select {
+				case results <- rec:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(seeds)
+		for i := 0; i < cfg.Games; i++ {
+			select {
+			case seeds <- cfg.Seed + int64(i):
+			case <-done:
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	report := newReport(cfg)
+	for {
+		select {
+		case rec, ok := <-results:
+			if !ok {
+				select {
+				case err := <-errs:
+					return nil, err
+				default:
+				}
+				report.finish()
+				return report, nil
+			}
+			report.add(rec)
+		case err := <-errs:
+			close(done)
+			return nil, err
+		}
+	}
+}
+
+// play plays a single game with the given seed.
+func play(cfg Config, seed int64) (*record, error) {
+	g, err := cfg.NewGame(rng.New(seed))
+	if err != nil {
+		return nil, err
+	}
+	rec := newRecord(cfg.NewStrategy())
+	res, err := headless.Run(g, rec)
+	if err != nil {
+		return nil, err
+	}
+	rec.seed = seed
+	rec.money = res.Money
+	rec.ante = res.Ante
+	rec.turns = res.Turns
+	return rec, nil
+}
@@ -0,0 +1,94 @@
+package sim_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+	"executive-chef/internal/rng"
+	"executive-chef/internal/sim"
+)
+
+var all = []ingredient.Ingredient{
+	{Name: "Chicken", Role: ingredient.Protein},
+	{Name: "Rice", Role: ingredient.Carb},
+	{Name: "Broccoli", Role: ingredient.Vegetable},
+}
+
+func newGame(r *rand.Rand) (*game.Game, error) {
+	d, err := deck.BuildFrom(r, all, deck.Recipe{Size: deck.DefaultSize})
+	if err != nil {
+		return nil, err
+	}
+	g := game.New(d, customer.NewDeckFrom(r, all), player.New(), nil, nil)
+	g.Rand = r
+	return g, nil
+}
+
+func config(workers int) sim.Config {
+	return sim.Config{
+		Games:       20,
+		Seed:        100,
+		Workers:     workers,
+		NewGame:     newGame,
+		NewStrategy: func() headless.Strategy { return &headless.FirstChoice{} },
+	}
+}
+
+func TestRunReportsEveryGame(t *testing.T) {
+	r, err := sim.Run(config(4))
+	require.NoError(t, err)
+
+	assert.Equal(t, 20, r.Games)
+	antes := 0
+	for _, n := range r.Antes {
+		antes += n
+	}
+	assert.Equal(t, 20, antes)
+	assert.GreaterOrEqual(t, r.Money.Max, r.Money.P50)
+	assert.GreaterOrEqual(t, r.Money.P50, r.Money.Min)
+	assert.Greater(t, r.Customers, 0)
+	assert.LessOrEqual(t, r.Served, r.Customers)
+	require.Len(t, r.Ingredients, 3)
+	assert.Equal(t, "Broccoli", r.Ingredients[0].Name)
+	require.NotEmpty(t, r.Income)
+	assert.Equal(t, 20, r.Income[0].Games)
+	require.Len(t, r.Outcomes, 20)
+	assert.Equal(t, int64(100), r.Outcomes[0].Seed)
+	assert.Equal(t, int64(119), r.Outcomes[19].Seed)
+
+	var out bytes.Buffer
+	require.NoError(t, r.Write(&out))
+	assert.Contains(t, out.String(), "Games: 20 (seeds 100-119)")
+	assert.Contains(t, out.String(), "Pick rate")
+}
+
+func TestRunIsReproducible(t *testing.T) {
+	a, err := sim.Run(config(1))
+	require.NoError(t, err)
+	b, err := sim.Run(config(8))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+}
+
+func TestRunStopsAtFailedGame(t *testing.T) {
+	cfg := config(2)
+	cfg.NewGame = func(r *rand.Rand) (*game.Game, error) {
+		if r.Int63() == rng.New(105).Int63() {
+			return nil, errors.New("no content")
+		}
+		return newGame(r)
+	}
+	_, err := sim.Run(cfg)
+	assert.EqualError(t, err, "sim: game with seed 105: no content")
+}
@@ -0,0 +1,92 @@
+package tournament
+
+import (
+	"math"
+
+	"executive-chef/internal/sim"
+)
+
+const (
+	// baseRating is the average rating of a tournament's entrants.
+	baseRating = 1500
+	// eloScale is the rating gap at which the stronger entrant is expected
+	// to win ten times as often as it loses.
+	eloScale = 400
+	// priorDraws is the number of virtual draws added between every pair
+	// of entrants, keeping ratings finite when one entrant wins every game.
+	priorDraws = 1
+	// rateIterations bounds the fitting of ratings.
+	rateIterations = 500
+)
+
+// rate fits a Bradley-Terry model to the head-to-head results of the given
+// games, counting draws as half a win for each side, and returns Elo-scale
+// ratings. outcomes[i][g] is entrant i's outcome in game g.
+func rate(outcomes [][]sim.Outcome, games []int) []float64 {
+	n := len(outcomes)
+	wins := make([]float64, n)
+	played := make([][]float64, n)
+	for i := range played {
+		played[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			played[i][j] += priorDraws
+			played[j][i] += priorDraws
+			wins[i] += priorDraws / 2.0
+			wins[j] += priorDraws / 2.0
+			for _, g := range games {
+				played[i][j]++
+				played[j][i]++
+				switch Compare(outcomes[i][g], outcomes[j][g]) {
+				case 1:
+					wins[i]++
+				case 0:
+					wins[i] += 0.5
+					wins[j] += 0.5
+				default:
+					wins[j]++
+				}
+			}
+		}
+	}
+
+	// Minorization-maximization updates (Hunter, 2004).
+	strength := make([]float64, n)
+	for i := range strength {
+		strength[i] = 1
+	}
+	next := make([]float64, n)
+	for iter := 0; iter < rateIterations; iter++ {
+		change := 0.0
+		for i := range next {
+			denom := 0.0
+			for j := range strength {
+				if j != i {
+					denom += played[i][j] / (strength[i] + strength[j])
+				}
+			}
+			next[i] = wins[i] / denom
+		}
+		// Normalize to a geometric mean of one.
+		logMean := 0.0
+		for _, s := range next {
+			logMean += math.Log(s)
+		}
+		norm := math.Exp(logMean / float64(n))
+		for i := range next {
+			next[i] /= norm
+			change = math.Max(change, math.Abs(next[i]-strength[i]))
+		}
+		strength, next = next, strength
+		if change < 1e-9 {
+			break
+		}
+	}
+
+	ratings := make([]float64, n)
+	for i, s := range strength {
+		ratings[i] = baseRating + eloScale*math.Log10(s)
+	}
+	return ratings
+}
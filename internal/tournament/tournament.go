@@ -0,0 +1,170 @@
+// Package tournament compares strategies by playing each of them on the same
+// seeded games and rating them from their head-to-head results.
+package tournament
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"text/tabwriter"
+
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+	"executive-chef/internal/rng"
+	"executive-chef/internal/sim"
+)
+
+// DefaultResamples is the number of bootstrap resamples used for confidence
+// intervals when Config.Resamples is zero.
+const DefaultResamples = 200
+
+// Entrant is a strategy taking part in a tournament.
+type Entrant struct {
+	Name        string
+	NewStrategy func() headless.Strategy
+}
+
+// Config describes a tournament.
+type Config struct {
+	Entrants []Entrant
+	// Games is the number of seeds every entrant plays.
+	Games int
+	// Seed is the seed of the first game; game i is played with Seed+i.
+	Seed int64
+	// Workers is the number of games played at once; see sim.Config.
+	Workers int
+	// NewGame builds a game drawing every random choice from r.
+	NewGame func(r *rand.Rand) (*game.Game, error)
+	// Resamples is the number of bootstrap resamples used to estimate
+	// confidence intervals. Zero uses DefaultResamples.
+	Resamples int
+}
+
+// Standing is an entrant's result in a tournament.
+type Standing struct {
+	Name string
+	// Rating is an Elo-scale rating centred on 1500. Low and High bound
+	// its 95% confidence interval.
+	Rating, Low, High float64
+	// Wins, Draws and Losses count the entrant's head-to-head results
+	// against every other entrant on the same seeds.
+	Wins, Draws, Losses int
+	// Money describes the money the entrant ended its games with.
+	Money sim.Distribution
+}
+
+// Run plays every seed with every entrant and returns the standings, best
+// rated first.
+func Run(cfg Config) ([]Standing, error) {
+	if len(cfg.Entrants) < 2 {
+		return nil, errors.New("tournament: need at least two entrants")
+	}
+	seen := make(map[string]bool)
+	outcomes := make([][]sim.Outcome, len(cfg.Entrants))
+	standings := make([]Standing, len(cfg.Entrants))
+	for i, e := range cfg.Entrants {
+		if seen[e.Name] {
+			return nil, fmt.Errorf("tournament: entrant %q listed twice", e.Name)
+		}
+		seen[e.Name] = true
+		report, err := sim.Run(sim.Config{
+			Games:       cfg.Games,
+			Seed:        cfg.Seed,
+			Workers:     cfg.Workers,
+			NewGame:     cfg.NewGame,
+			NewStrategy: e.NewStrategy,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("tournament: %s: %w", e.Name, err)
+		}
+		outcomes[i] = report.Outcomes
+		standings[i] = Standing{Name: e.Name, Money: report.Money}
+	}
+
+	games := make([]int, cfg.Games)
+	for g := range games {
+		games[g] = g
+	}
+	for i := range standings {
+		for j := range standings {
+			if i == j {
+				continue
+			}
+			for g := 0; g < cfg.Games; g++ {
+				switch Compare(outcomes[i][g], outcomes[j][g]) {
+				case 1:
+					standings[i].Wins++
+				case 0:
+					standings[i].Draws++
+				default:
+					standings[i].Losses++
+				}
+			}
+		}
+	}
+	ratings := rate(outcomes, games)
+
+	resamples := cfg.Resamples
+	if resamples == 0 {
+		resamples = DefaultResamples
+	}
+	r := rng.New(cfg.Seed)
+	samples := make([][]float64, len(standings))
+	sample := make([]int, cfg.Games)
+	for n := 0; n < resamples; n++ {
+		for g := range sample {
+			sample[g] = r.Intn(cfg.Games)
+		}
+		for i, rating := range rate(outcomes, sample) {
+			samples[i] = append(samples[i], rating)
+		}
+	}
+	for i := range standings {
+		standings[i].Rating = ratings[i]
+		standings[i].Low, standings[i].High = ratings[i], ratings[i]
+		if len(samples[i]) > 0 {
+			sort.Float64s(samples[i])
+			standings[i].Low = quantile(samples[i], 0.025)
+			standings[i].High = quantile(samples[i], 0.975)
+		}
+	}
+	sort.SliceStable(standings, func(i, j int) bool { return standings[i].Rating > standings[j].Rating })
+	return standings, nil
+}
+
+// Compare orders two outcomes of the same seed. The game reaching the later
+// ante wins, and within the same ante the one ending with more money wins.
+// It returns 1 if a wins, -1 if b wins and 0 for a draw.
+func Compare(a, b sim.Outcome) int {
+	switch {
+	case a.Ante != b.Ante:
+		if a.Ante > b.Ante {
+			return 1
+		}
+		return -1
+	case a.Money != b.Money:
+		if a.Money > b.Money {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+func quantile(sorted []float64, q float64) float64 {
+	i := int(q * float64(len(sorted)-1))
+	return sorted[i]
+}
+
+// WriteStandings prints standings as a table.
+func WriteStandings(w io.Writer, standings []Standing) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Rank\tBot\tRating\t95% CI\tW-D-L\tMean money\tMedian money")
+	for i, s := range standings {
+		fmt.Fprintf(tw, "%d\t%s\t%.0f\t%.0f-%.0f\t%d-%d-%d\t$%.1f\t$%d\n",
+			i+1, s.Name, s.Rating, s.Low, s.High, s.Wins, s.Draws, s.Losses, s.Money.Mean, s.Money.P50)
+	}
+	return tw.Flush()
+}
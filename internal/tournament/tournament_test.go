@@ -0,0 +1,94 @@
+package tournament_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/deck"
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
+	"executive-chef/internal/sim"
+	"executive-chef/internal/tournament"
+)
+
+func newGame(r *rand.Rand) (*game.Game, error) {
+	all := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein},
+		{Name: "Rice", Role: ingredient.Carb},
+		{Name: "Broccoli", Role: ingredient.Vegetable},
+	}
+	d, err := deck.BuildFrom(r, all, deck.Recipe{Size: deck.DefaultSize})
+	if err != nil {
+		return nil, err
+	}
+	g := game.New(d, customer.NewDeckFrom(r, all), player.New(), nil, nil)
+	g.Rand = r
+	return g, nil
+}
+
+// idle drafts but never designs a dish, so it never earns money.
+func idle() headless.Strategy {
+	return headless.StrategyFunc(func(e game.Event) game.Action {
+		switch e.(type) {
+		case game.DraftOptionsEvent:
+			return game.DraftSelectionAction{Index: 0}
+		case game.DesignOptionsEvent:
+			return game.FinishDesignAction{}
+		case game.ServiceResultEvent:
+			return game.ContinueAction{}
+		}
+		return nil
+	})
+}
+
+func TestRunRanksStrongerStrategyFirst(t *testing.T) {
+	cfg := tournament.Config{
+		Entrants: []tournament.Entrant{
+			{Name: "idle", NewStrategy: idle},
+			{Name: "first", NewStrategy: func() headless.Strategy { return &headless.FirstChoice{} }},
+		},
+		Games:     30,
+		Seed:      1,
+		NewGame:   newGame,
+		Resamples: 50,
+	}
+	standings, err := tournament.Run(cfg)
+	require.NoError(t, err)
+	require.Len(t, standings, 2)
+
+	first, idle := standings[0], standings[1]
+	assert.Equal(t, "first", first.Name)
+	assert.Greater(t, first.Rating, 1500.0)
+	assert.InDelta(t, 3000, first.Rating+idle.Rating, 1e-6)
+	assert.LessOrEqual(t, first.Low, first.Rating)
+	assert.GreaterOrEqual(t, first.High, first.Rating)
+	assert.Equal(t, 30, first.Wins+first.Draws+first.Losses)
+	assert.Equal(t, first.Wins, idle.Losses)
+
+	again, err := tournament.Run(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, standings, again)
+
+	var out bytes.Buffer
+	require.NoError(t, tournament.WriteStandings(&out, standings))
+	assert.Contains(t, out.String(), "95% CI")
+}
+
+func TestRunRejectsDuplicateEntrants(t *testing.T) {
+	e := tournament.Entrant{Name: "idle", NewStrategy: idle}
+	_, err := tournament.Run(tournament.Config{Entrants: []tournament.Entrant{e, e}, Games: 1, NewGame: newGame})
+	assert.EqualError(t, err, `tournament: entrant "idle" listed twice`)
+}
+
+func TestCompare(t *testing.T) {
+	assert.Equal(t, 1, tournament.Compare(sim.Outcome{Ante: 3, Money: 10}, sim.Outcome{Ante: 2, Money: 40}))
+	assert.Equal(t, -1, tournament.Compare(sim.Outcome{Ante: 2, Money: 10}, sim.Outcome{Ante: 2, Money: 11}))
+	assert.Equal(t, 0, tournament.Compare(sim.Outcome{Ante: 2, Money: 10}, sim.Outcome{Ante: 2, Money: 10}))
+}
@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"executive-chef/internal/customer"
+	"executive-chef/internal/game"
+)
+
+func TestAnteStatusShowsProgressAndBoss(t *testing.T) {
+	m := initialModel(nil)
+	m.Update(game.StateSnapshotEvent{State: game.State{
+		Turn: 5, Phase: game.PhaseDraft, Ante: 2, AnteTurns: 3, Target: 25, Boss: &customer.Bosses[0], Money: 12,
+	}})
+
+	got := stripANSI(m.anteStatus())
+	assert.Equal(t, "Ante 2 (turn 2/3) • Target: $12/$25 • Boss: The Critic, rejects any dish with a Carb", got)
+}
+
+func TestGameOverEventSwitchesMode(t *testing.T) {
+	m := initialModel(nil)
+	m.Update(game.GameOverEvent{Turn: 3, Ante: 1, Money: 4, Reason: "missed the ante 1 target of $10"})
+	_, ok := m.mode.(*gameOverMode)
+	assert.True(t, ok)
+
+	// Snapshots still update the state but leave the game over screen.
+	m.Update(game.StateSnapshotEvent{State: game.State{Turn: 4, Phase: game.PhaseDraft}})
+	_, ok = m.mode.(*gameOverMode)
+	assert.True(t, ok)
+}
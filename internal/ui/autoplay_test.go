@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+)
+
+func TestAutoplaySendsActionsInOrder(t *testing.T) {
+	actions := make(chan game.Action, 2)
+	m := initialModel(actions)
+	WithAutoplay(headless.StrategyFunc(func(e game.Event) game.Action {
+		if _, ok := e.(game.DraftOptionsEvent); ok {
+			return game.DraftSelectionAction{Index: 0}
+		}
+		return nil
+	}), 0)(m)
+
+	cmd, consumed := m.autoplayUpdate(game.DraftOptionsEvent{})
+	assert.False(t, consumed)
+	assert.NotNil(t, cmd)
+	assert.True(t, m.autoBusy)
+
+	// A second event while the first action is pending only queues.
+	cmd2, _ := m.autoplayUpdate(game.DraftOptionsEvent{})
+	assert.Nil(t, cmd2)
+	assert.Len(t, m.autoQueue, 2)
+
+	send, consumed := m.autoplayUpdate(autoTickMsg{})
+	assert.True(t, consumed)
+	assert.Equal(t, autoSentMsg{}, send())
+	assert.Equal(t, game.DraftSelectionAction{Index: 0}, <-actions)
+
+	next, _ := m.autoplayUpdate(autoSentMsg{})
+	assert.NotNil(t, next)
+	assert.Len(t, m.autoQueue, 1)
+}
+
+func TestAutoplayIgnoresKeys(t *testing.T) {
+	m := initialModel(make(chan game.Action))
+	WithAutoplay(&headless.FirstChoice{}, 0)(m)
+
+	cmd, consumed := m.autoplayUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, consumed)
+	assert.Nil(t, cmd)
+
+	_, consumed = m.autoplayUpdate(tea.KeyMsg{Type: tea.KeyCtrlR})
+	assert.False(t, consumed)
+}
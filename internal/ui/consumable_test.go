@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"executive-chef/internal/consumable"
+	"executive-chef/internal/game"
+)
+
+func TestUseConsumableSendsAction(t *testing.T) {
+	actions := make(chan game.Action, 1)
+	m := &model{actions: actions, state: game.State{
+		Phase:       game.PhaseDesign,
+		Consumables: []consumable.Consumable{{Kind: consumable.Peek}, {Kind: consumable.Transmute}},
+	}}
+
+	assert.True(t, m.useConsumable("2", 4))
+	assert.Equal(t, game.UseConsumableAction{Index: 1, Target: 4}, <-actions)
+}
+
+func TestUseConsumableRejectsWrongPhase(t *testing.T) {
+	actions := make(chan game.Action, 1)
+	m := &model{actions: actions, state: game.State{
+		Phase:       game.PhaseService,
+		Consumables: []consumable.Consumable{{Kind: consumable.Reroll}},
+	}}
+
+	assert.True(t, m.useConsumable("1", -1))
+	assert.Empty(t, actions)
+	assert.Contains(t, m.message, "can't be used")
+	assert.False(t, m.useConsumable("3", -1))
+}
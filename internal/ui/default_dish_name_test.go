@@ -26,7 +26,34 @@ func TestDefaultDishNameNonVegetable(t *testing.T) {
 	}
 	selected := map[int]bool{0: true, 1: true}
 	got := defaultDishName(selected, drafted)
-	want := "Chicken and Rice"
+	want := "Chicken and Rice Bowl"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestDefaultDishNamePlate(t *testing.T) {
+	drafted := []ingredient.Ingredient{
+		{Name: "Potato", Role: ingredient.Carb},
+		{Name: "Carrot", Role: ingredient.Vegetable},
+		{Name: "Beef", Role: ingredient.Protein},
+	}
+	selected := map[int]bool{0: true, 1: true, 2: true}
+	got := defaultDishName(selected, drafted)
+	want := "Beef Plate"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestDefaultDishNameNoArchetype(t *testing.T) {
+	drafted := []ingredient.Ingredient{
+		{Name: "Chicken", Role: ingredient.Protein},
+		{Name: "Cheese", Role: ingredient.Dairy},
+	}
+	selected := map[int]bool{0: true, 1: true}
+	got := defaultDishName(selected, drafted)
+	want := "Chicken and Cheese"
 	if got != want {
 		t.Fatalf("got %q, want %q", got, want)
 	}
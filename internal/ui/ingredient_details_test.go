@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"testing"
+
+	"executive-chef/internal/ingredient"
+)
+
+func TestIngredientDetails(t *testing.T) {
+	ing := ingredient.Ingredient{
+		Name:    "Salmon",
+		Role:    ingredient.Protein,
+		Cost:    4,
+		Rarity:  ingredient.Rare,
+		Cuisine: "Japanese",
+		Flavor:  ingredient.Flavor{Salty: 1, Umami: 2},
+	}
+	got := ingredientDetails(ing)
+	want := " • $4 Rare Japanese • salty 1, umami 2"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got := ingredientDetails(ingredient.Ingredient{Name: "Rice"}); got != "" {
+		t.Fatalf("got %q, want empty", got)
+	}
+}
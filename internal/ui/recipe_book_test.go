@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"executive-chef/internal/recipe"
+)
+
+func TestRecipeBookViewHidesUndiscovered(t *testing.T) {
+	m := initialModel(nil)
+	m.state.Recipes = []recipe.Entry{
+		{Size: 3, Bonus: 4},
+		{Name: "Grilled Cheese", Ingredients: []string{"Bread", "Cheese"}, Size: 2, Bonus: 2, Discovered: true},
+	}
+
+	out := stripANSI(m.recipeBookView())
+	assert.Contains(t, out, "Recipe Book (1/2 discovered)")
+	assert.Contains(t, out, "???: ? + ? + ? (+$4)")
+	assert.Contains(t, out, "Grilled Cheese: Bread + Cheese (+$2)")
+}
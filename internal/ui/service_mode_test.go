@@ -40,8 +40,9 @@ func TestServiceModeViewShowsAllCravings(t *testing.T) {
 			{Name: "Cheese", Role: ingredient.Protein},
 		},
 	}
-	sm := serviceMode{current: &game.ServiceResultEvent{Customer: c, Dish: d, Payment: 3}}
-	out := stripANSI(sm.View(&model{}))
+	sm := serviceMode{}
+	m := &model{state: game.State{Result: &game.ServiceResultEvent{Customer: c, Dish: d, Payment: 3, Craving: 1, Satisfaction: game.Full}}}
+	out := stripANSI(sm.View(m))
 	lines := strings.Split(strings.TrimSpace(out), "\n")
 	require.Equal(t, 4, len(lines))
 	assert.Equal(t, "Service", strings.TrimSpace(lines[0]))
@@ -49,3 +50,49 @@ func TestServiceModeViewShowsAllCravings(t *testing.T) {
 	assert.Equal(t, "Lettuce, Tomato", strings.TrimSpace(lines[2]))
 	assert.Equal(t, "Tomato, Cheese -> Salad ($3)", strings.TrimSpace(lines[3]))
 }
+
+func TestServiceModeViewShowsSynergy(t *testing.T) {
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	c := customer.Customer{
+		Name:     "Bob",
+		Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{rice}}},
+	}
+	d := &dish.Dish{Name: "Rice Bowl", Ingredients: []ingredient.Ingredient{rice}}
+	sm := serviceMode{}
+	m := &model{state: game.State{Result: &game.ServiceResultEvent{Customer: c, Dish: d, Payment: 7, Synergy: 2}}}
+	out := stripANSI(sm.View(m))
+	assert.Contains(t, out, "Rice -> Rice Bowl ($7) synergy +2")
+}
+
+func TestServiceModeViewShowsPartialAndPenalty(t *testing.T) {
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	beef := ingredient.Ingredient{Name: "Beef", Role: ingredient.Protein}
+	c := customer.Customer{
+		Name:     "Carol",
+		Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{rice, beef}}},
+	}
+	d := &dish.Dish{Name: "Rice", Ingredients: []ingredient.Ingredient{rice, {Name: "Apple", Role: ingredient.Fruit}}}
+	sm := serviceMode{}
+	m := &model{state: game.State{Result: &game.ServiceResultEvent{
+		Customer: c, Dish: d, Payment: 1, Satisfaction: game.Partial, Extras: 1, Penalty: 1,
+	}}}
+	out := stripANSI(sm.View(m))
+	assert.Contains(t, out, "Rice, Beef -> Rice ($1) partial 1 extra -$1")
+}
+
+func TestServiceResultsNameRivalRestaurants(t *testing.T) {
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	c := customer.Customer{
+		Name:     "Dave",
+		Cravings: []customer.Craving{{Ingredients: []ingredient.Ingredient{rice}}},
+	}
+	d := &dish.Dish{Name: "Rice Bowl", Ingredients: []ingredient.Ingredient{rice}}
+	rival := game.ServiceResultEvent{Player: 1, Customer: c, Dish: d, Payment: 4}
+	m := &model{state: game.State{Result: &rival}}
+	assert.Contains(t, stripANSI((&serviceMode{}).View(m)), "Rice -> player 1's Rice Bowl ($4)")
+	assert.Equal(t, "Dave served player 1's Rice Bowl for $4", eventString(rival, 0))
+
+	m.player = 1
+	assert.Contains(t, stripANSI((&serviceMode{}).View(m)), "Rice -> Rice Bowl ($4)")
+	assert.Equal(t, "Dave served Rice Bowl for $4", eventString(rival, 1))
+}
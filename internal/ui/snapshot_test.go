@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"executive-chef/internal/dish"
+	"executive-chef/internal/game"
+	"executive-chef/internal/ingredient"
+)
+
+func TestSnapshotEntersModeForPhase(t *testing.T) {
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	m := initialModel(make(chan game.Action, 1))
+	design := game.State{Turn: 2, Phase: game.PhaseDesign, Drafted: []ingredient.Ingredient{tofu}, DishesLeft: 2}
+
+	m.Update(game.StateSnapshotEvent{State: design})
+	d, ok := m.mode.(*designMode)
+	require.True(t, ok)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, d.selected[0])
+
+	// Later snapshots of the same phase keep the mode and its selection.
+	design.DishesLeft = 1
+	m.Update(game.StateSnapshotEvent{State: design})
+	assert.Same(t, d, m.mode)
+	assert.True(t, d.selected[0])
+	assert.Equal(t, "Tofu", d.name.Value())
+	assert.Equal(t, 1, m.state.DishesLeft)
+
+	m.Update(game.StateSnapshotEvent{State: game.State{Turn: 2, Phase: game.PhaseService}})
+	assert.IsType(t, &serviceMode{}, m.mode)
+}
+
+func TestDesignModeRendersFromState(t *testing.T) {
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	rice := ingredient.Ingredient{Name: "Rice", Role: ingredient.Carb}
+	actions := make(chan game.Action, 1)
+	m := initialModel(actions)
+	m.Update(game.StateSnapshotEvent{State: game.State{
+		Turn:    1,
+		Phase:   game.PhaseDesign,
+		Drafted: []ingredient.Ingredient{tofu},
+		Menu: []game.MenuItem{
+			{Dish: dish.Dish{Name: "Old", Ingredients: []ingredient.Ingredient{rice}}},
+			{Dish: dish.Dish{Name: "Fresh", Ingredients: []ingredient.Ingredient{tofu}}, Have: 1, New: true},
+		},
+	}})
+
+	out := stripANSI(m.View())
+	assert.Contains(t, out, "- Old (0/1)")
+	assert.Contains(t, out, "  Fresh")
+
+	// Only dishes created this turn can be deleted.
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	assert.Equal(t, game.DeleteDishAction{Index: 1}, <-actions)
+
+	// With no dishes left to create, confirming the name finishes.
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, "dish limit reached. press enter again to finish", m.message)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, game.FinishDesignAction{}, <-actions)
+}
+
+func TestDraftModeShowsPendingPack(t *testing.T) {
+	tofu := ingredient.Ingredient{Name: "Tofu", Role: ingredient.Protein}
+	actions := make(chan game.Action, 1)
+	m := initialModel(actions)
+	m.mode = &serviceMode{}
+
+	m.Update(game.StateSnapshotEvent{State: game.State{Turn: 2, Phase: game.PhaseDraft, Reveal: []ingredient.Ingredient{tofu}, Picks: 2}})
+
+	require.IsType(t, &draftMode{}, m.mode)
+	assert.Contains(t, m.mode.Status(m), "Pick 2 more")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, game.DraftSelectionAction{Index: 0}, <-actions)
+}
+
+func TestCtrlLRequestsSnapshot(t *testing.T) {
+	actions := make(chan game.Action, 1)
+	m := initialModel(actions)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	require.NotNil(t, cmd)
+	cmd()
+	assert.Equal(t, game.RequestSnapshotAction{}, <-actions)
+}
@@ -3,15 +3,19 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"executive-chef/internal/customer"
 	"executive-chef/internal/dish"
 	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
 	"executive-chef/internal/ingredient"
+	"executive-chef/internal/player"
 )
 
 const logWidth = 30
@@ -26,22 +30,98 @@ type uiMode interface {
 }
 
 type model struct {
-	actions     chan<- game.Action
-	mode        uiMode
-	events      []string
-	vp          viewport.Model
-	turn        int
-	phase       game.Phase
-	dishes      []dish.Dish
-	ingredients []ingredient.Ingredient
+	actions chan<- game.Action
+	// player is the ID of the player using the UI.
+	player int
+	mode   uiMode
+	events []string
+	vp     viewport.Model
+	// state is the game as the engine last showed it to the player. The UI
+	// renders from it and never changes it.
+	state       game.State
 	message     string
 	width       int
-	money       int
+	showRecipes bool
+
+	// autoplay, when set, chooses every action in place of the keyboard.
+	autoplay  headless.Strategy
+	autoDelay time.Duration
+	autoQueue []game.Action
+	autoBusy  bool
+}
+
+// Option configures Run.
+type Option func(*model)
+
+// WithAutoplay lets s play the game, sending one action every delay so the
+// game can be watched. Keyboard input other than quitting and the recipe
+// book is ignored.
+func WithAutoplay(s headless.Strategy, delay time.Duration) Option {
+	return func(m *model) {
+		m.autoplay = s
+		m.autoDelay = delay
+	}
+}
+
+// WithPlayer sets the ID of the player using the UI, for games with more
+// than one player. Customers served by other restaurants are shown with the
+// ID of the player who served them.
+func WithPlayer(id int) Option {
+	return func(m *model) { m.player = id }
+}
+
+// autoTickMsg signals that the next autoplay action is due.
+type autoTickMsg struct{}
+
+// autoSentMsg signals that an autoplay action was delivered to the game.
+type autoSentMsg struct{}
+
+// autoplayUpdate queues the strategy's response to events and delivers queued
+// actions one at a time. Actions are sent from a command so the UI never
+// blocks on the game. It reports whether msg was consumed.
+func (m *model) autoplayUpdate(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case game.Event:
+		if a := m.autoplay.Act(msg); a != nil {
+			m.autoQueue = append(m.autoQueue, a)
+			if !m.autoBusy {
+				return m.autoTick(), false
+			}
+		}
+	case autoTickMsg:
+		a := m.autoQueue[0]
+		m.autoQueue = m.autoQueue[1:]
+		actions := m.actions
+		return func() tea.Msg {
+			actions <- a
+			return autoSentMsg{}
+		}, true
+	case autoSentMsg:
+		m.autoBusy = false
+		if len(m.autoQueue) > 0 {
+			return m.autoTick(), true
+		}
+		return nil, true
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return tea.Quit, true
+		case "ctrl+r":
+			return nil, false
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+func (m *model) autoTick() tea.Cmd {
+	m.autoBusy = true
+	return tea.Tick(m.autoDelay, func(time.Time) tea.Msg { return autoTickMsg{} })
 }
 
 func initialModel(actions chan<- game.Action) *model {
 	m := &model{actions: actions}
-	m.mode = &draftMode{remaining: -1}
+	m.mode = &draftMode{}
 	m.vp = viewport.New(logWidth-2, 7)
 	return m
 }
@@ -51,29 +131,29 @@ func (m *model) Init() tea.Cmd {
 }
 
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var autoCmd tea.Cmd
+	if m.autoplay != nil {
+		var consumed bool
+		if autoCmd, consumed = m.autoplayUpdate(msg); consumed {
+			return m, autoCmd
+		}
+	}
+
+	var stateCmd tea.Cmd
 	if e, ok := msg.(game.Event); ok {
-		if str := eventString(e); str != "" {
+		if str := eventString(e, m.player); str != "" {
 			m.events = append(m.events, str)
 			m.vp.SetContent(strings.Join(m.events, "\n"))
 			m.vp.GotoBottom()
 		}
 		switch ev := e.(type) {
-		case game.PhaseEvent:
-			m.turn = ev.Turn
-			m.phase = ev.Phase
-		case game.IngredientDraftedEvent:
-			m.ingredients = append(m.ingredients, ev.Ingredient)
-		case game.DishCreatedEvent:
-			m.dishes = append(m.dishes, ev.Dish)
-		case game.DishDeletedEvent:
-			if ev.Index >= 0 && ev.Index < len(m.dishes) {
-				m.dishes = append(m.dishes[:ev.Index], m.dishes[ev.Index+1:]...)
-			}
-		case game.ServiceEndEvent:
-			m.ingredients = nil
-		}
-		if pay, ok := e.(game.ServiceResultEvent); ok {
-			m.money = pay.Money
+		case game.StateSnapshotEvent:
+			stateCmd = m.apply(ev)
+		case game.GameOverEvent:
+			m.mode = &gameOverMode{event: ev}
+			return m, m.mode.Init(m)
+		case game.ActionRejectedEvent:
+			m.message = ev.Reason
 		}
 	}
 
@@ -81,6 +161,20 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = wm.Width
 	}
 
+	if km, ok := msg.(tea.KeyMsg); ok && km.String() == "ctrl+r" {
+		m.showRecipes = !m.showRecipes
+		return m, nil
+	}
+
+	// ctrl+l asks the game for a snapshot to redraw from, in case the UI
+	// has fallen out of step with it.
+	if km, ok := msg.(tea.KeyMsg); ok && km.String() == "ctrl+l" {
+		return m, func() tea.Msg {
+			m.actions <- game.RequestSnapshotAction{}
+			return nil
+		}
+	}
+
 	var vpCmd tea.Cmd
 	m.vp, vpCmd = m.vp.Update(msg)
 	m.vp.Width = logWidth - 2
@@ -89,32 +183,37 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if newMode != nil {
 		m.mode = newMode
 		initCmd := m.mode.Init(m)
-		return m, tea.Batch(modeCmd, initCmd, vpCmd)
+		return m, tea.Batch(stateCmd, modeCmd, initCmd, vpCmd, autoCmd)
 	}
 
-	return m, tea.Batch(vpCmd, modeCmd)
+	return m, tea.Batch(stateCmd, vpCmd, modeCmd, autoCmd)
 }
 
 func (m *model) View() string {
 	main := m.mode.View(m)
+	if m.showRecipes {
+		main = m.recipeBookView()
+	}
 
 	var infoBuilder strings.Builder
 	infoBuilder.WriteString(titleStyle.Render("Game Info") + "\n")
 	infoBuilder.WriteString(
 		fmt.Sprintf(
 			"Turn: %d\nPhase: %s\nMoney: $%d\n",
-			m.turn, m.phase, m.money,
+			m.state.Turn, m.state.Phase, m.state.Money,
 		),
 	)
+	if m.state.Ante > 0 {
+		infoBuilder.WriteString(m.anteStatus() + "\n")
+	}
 	infoBuilder.WriteString("Dishes:\n")
-	if len(m.dishes) == 0 {
+	if len(m.state.Menu) == 0 {
 		infoBuilder.WriteString("  (none)\n")
 	} else {
-		for _, d := range m.dishes {
-			have, total := m.ingredientCount(d)
-			line := fmt.Sprintf("- %s", d.Name)
-			if have < total {
-				line = fmt.Sprintf("%s (%d/%d)", line, have, total)
+		for _, item := range m.state.Menu {
+			line := fmt.Sprintf("- %s", item.Dish.Name)
+			if !item.Available() {
+				line = fmt.Sprintf("%s (%d/%d)", line, item.Have, len(item.Dish.Ingredients))
 				line = missingStyle.Render(line)
 			} else {
 				line = servedStyle.Render(line)
@@ -122,6 +221,18 @@ func (m *model) View() string {
 			infoBuilder.WriteString(line + "\n")
 		}
 	}
+	infoBuilder.WriteString("Consumables:")
+	if len(m.state.Consumables) == 0 {
+		infoBuilder.WriteString(" (none)")
+	}
+	for i, c := range m.state.Consumables {
+		line := fmt.Sprintf(" [%d] %s", i+1, c.Kind)
+		if !m.state.Usable(i) {
+			line = disabledStyle.Render(line)
+		}
+		infoBuilder.WriteString(line)
+	}
+	infoBuilder.WriteString("\n")
 	info := paneStyle.Render(infoBuilder.String())
 	logView := paneStyle.Render(titleStyle.Render("Events") + "\n" + m.vp.View())
 
@@ -132,39 +243,120 @@ func (m *model) View() string {
 	main = lipgloss.NewStyle().Width(mainWidth).Render(main)
 
 	content := lipgloss.JoinHorizontal(lipgloss.Top, main, logView)
-	status := statusStyle.Render(m.mode.Status(m))
+	statusText := m.mode.Status(m) + " • ctrl+r: recipe book"
+	if m.autoplay != nil {
+		statusText = "autoplay • ctrl+r: recipe book • q: quit"
+	}
+	if m.showRecipes {
+		statusText = "ctrl+r: close recipe book"
+	}
+	status := statusStyle.Render(statusText)
 	message := messageStyle.Render(m.message)
 	return lipgloss.JoinVertical(lipgloss.Left, info, content, status, message)
 }
 
-func (m *model) ingredientCount(d dish.Dish) (have, total int) {
-	total = len(d.Ingredients)
-	for _, need := range d.Ingredients {
-		for _, ing := range m.ingredients {
-			if ing == need {
-				have++
-				break
-			}
+func (m *model) hasDrafted(ing ingredient.Ingredient) bool {
+	for _, drafted := range m.state.Drafted {
+		if drafted == ing {
+			return true
 		}
 	}
-	return have, total
+	return false
 }
 
-func (m *model) hasIngredients(d dish.Dish) bool {
-	have, total := m.ingredientCount(d)
-	return have == total
+// recipeBookView lists discovered recipes and hides undiscovered ones.
+func (m *model) recipeBookView() string {
+	var b strings.Builder
+	recipes := m.state.Recipes
+	discovered := 0
+	for _, r := range recipes {
+		if r.Discovered {
+			discovered++
+		}
+	}
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Recipe Book (%d/%d discovered)", discovered, len(recipes))) + "\n")
+	if len(recipes) == 0 {
+		b.WriteString("(no recipes)\n")
+	}
+	for _, r := range recipes {
+		if r.Discovered {
+			b.WriteString(servedStyle.Render(fmt.Sprintf("%s: %s (+$%d)", r.Name, strings.Join(r.Ingredients, " + "), r.Bonus)) + "\n")
+			continue
+		}
+		hidden := make([]string, r.Size)
+		for i := range hidden {
+			hidden[i] = "?"
+		}
+		b.WriteString(disabledStyle.Render(fmt.Sprintf("???: %s (+$%d)", strings.Join(hidden, " + "), r.Bonus)) + "\n")
+	}
+	return paneStyle.Render(b.String())
 }
 
-func (m *model) hasDrafted(ing ingredient.Ingredient) bool {
-	for _, drafted := range m.ingredients {
-		if drafted == ing {
-			return true
-		}
+// anteStatus summarises the current ante, its money target and the boss.
+func (m *model) anteStatus() string {
+	st := m.state
+	anteTurn := st.Turn - (st.Ante-1)*st.AnteTurns
+	if anteTurn < 1 {
+		anteTurn = 1
 	}
-	return false
+	progress := fmt.Sprintf("$%d/$%d", st.Money, st.Target)
+	if st.Money >= st.Target {
+		progress = servedStyle.Render(progress)
+	} else {
+		progress = missingStyle.Render(progress)
+	}
+	line := fmt.Sprintf("Ante %d (turn %d/%d) • Target: %s", st.Ante, anteTurn, st.AnteTurns, progress)
+	if st.Boss != nil {
+		line += fmt.Sprintf(" • Boss: %s, %s", st.Boss.Name, st.Boss.Description)
+	}
+	return line
 }
 
-func eventString(e game.Event) string {
+// useConsumable sends a UseConsumableAction if key selects a slot in the
+// player's consumable hand. target is the drafted ingredient index used by
+// Transmute. It reports whether the key was handled.
+func (m *model) useConsumable(key string, target int) bool {
+	if len(key) != 1 || key[0] < '1' || key[0] > '9' {
+		return false
+	}
+	idx := int(key[0] - '1')
+	if idx >= len(m.state.Consumables) {
+		return false
+	}
+	c := m.state.Consumables[idx]
+	if !m.state.Usable(idx) {
+		m.message = fmt.Sprintf("%s can't be used during %s", c.Kind, m.state.Phase)
+		return true
+	}
+	m.actions <- game.UseConsumableAction{Index: idx, Target: target}
+	m.message = fmt.Sprintf("used %s: %s", c.Kind, c.Description())
+	return true
+}
+
+// apply makes a snapshot's state the one the UI renders from, entering the
+// mode for its phase if the UI isn't in it yet.
+func (m *model) apply(s game.StateSnapshotEvent) tea.Cmd {
+	prev := m.state
+	m.state = s.State
+	if _, over := m.mode.(*gameOverMode); over {
+		return nil
+	}
+	if prev.Turn == s.Turn && prev.Phase == s.Phase {
+		return nil
+	}
+	switch s.Phase {
+	case game.PhaseDesign:
+		m.mode = &designMode{}
+	case game.PhaseService:
+		m.mode = &serviceMode{}
+	default:
+		m.mode = &draftMode{}
+	}
+	return m.mode.Init(m)
+}
+
+// eventString describes e for the event log of the player with ID self.
+func eventString(e game.Event, self int) string {
 	switch e := e.(type) {
 	case game.PhaseEvent:
 		return fmt.Sprintf("Turn %d: %s phase", e.Turn, e.Phase)
@@ -185,11 +377,54 @@ func eventString(e game.Event) string {
 		dishName := "no dish"
 		if e.Dish != nil {
 			dishName = e.Dish.Name
+			if e.Player != self {
+				dishName = fmt.Sprintf("player %d's %s", e.Player, e.Dish.Name)
+			}
+		}
+		if e.Payment > 0 && e.Satisfaction == game.Partial {
+			return fmt.Sprintf("%s partly enjoyed %s for $%d", e.Customer.Name, dishName, e.Payment)
 		}
 		if e.Payment > 0 {
+			if e.Doubled {
+				return fmt.Sprintf("%s served %s for $%d (doubled)", e.Customer.Name, dishName, e.Payment)
+			}
 			return fmt.Sprintf("%s served %s for $%d", e.Customer.Name, dishName, e.Payment)
 		}
 		return fmt.Sprintf("%s was not served", e.Customer.Name)
+	case game.ConsumableGainedEvent:
+		return fmt.Sprintf("Consumable gained: %s", e.Consumable.Kind)
+	case game.ConsumableUsedEvent:
+		return fmt.Sprintf("Consumable used: %s", e.Consumable.Kind)
+	case game.CustomersPeekedEvent:
+		return fmt.Sprintf("Peeked at %d customers", len(e.Customers))
+	case game.IngredientTransmutedEvent:
+		return fmt.Sprintf("%s transmuted into %s", e.From.Name, e.To.Name)
+	case game.ActionRejectedEvent:
+		if e.Action == nil {
+			return fmt.Sprintf("Rejected: %s", e.Reason)
+		}
+		return fmt.Sprintf("Rejected %s: %s", e.Action.ActionType(), e.Reason)
+	case game.RecipeDiscoveredEvent:
+		return fmt.Sprintf("Recipe discovered: %s", e.Entry.Name)
+	case game.RecipeBookEvent, game.StateSnapshotEvent:
+		return ""
+	case game.DeckSummaryEvent:
+		var parts []string
+		for _, entry := range e.Composition {
+			parts = append(parts, fmt.Sprintf("%s x%d", entry.Ingredient.Name, entry.Count))
+		}
+		return fmt.Sprintf("Deck: %d cards (%s)", e.Size, strings.Join(parts, ", "))
+	case game.AnteStartEvent:
+		return fmt.Sprintf("Ante %d: reach $%d", e.Ante, e.Target)
+	case game.AnteEndEvent:
+		if e.Passed {
+			return fmt.Sprintf("Ante %d cleared with $%d", e.Ante, e.Money)
+		}
+		return fmt.Sprintf("Ante %d failed: $%d/$%d", e.Ante, e.Money, e.Target)
+	case game.GameOverEvent:
+		return "Game over"
+	case fmt.Stringer:
+		return e.String()
 	default:
 		return e.EventType()
 	}
@@ -197,9 +432,7 @@ func eventString(e game.Event) string {
 
 // ---- Draft Mode ----
 type draftMode struct {
-	draft     []ingredient.Ingredient
-	cursor    int
-	remaining int
+	cursor int
 }
 
 func (d *draftMode) Init(m *model) tea.Cmd {
@@ -208,15 +441,12 @@ func (d *draftMode) Init(m *model) tea.Cmd {
 }
 
 func (d *draftMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
+	reveal := m.state.Reveal
 	switch msg := msg.(type) {
-	case game.DraftOptionsEvent:
-		d.draft = msg.Reveal
-		d.remaining = msg.Picks
-		if d.cursor >= len(d.draft) {
-			d.cursor = len(d.draft) - 1
+	case game.StateSnapshotEvent:
+		if d.cursor >= len(reveal) {
+			d.cursor = max(len(reveal)-1, 0)
 		}
-	case game.DesignOptionsEvent:
-		return &designMode{drafted: msg.Drafted}, nil
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
@@ -226,16 +456,15 @@ func (d *draftMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
 				d.cursor--
 			}
 		case "down", "j":
-			if d.cursor < len(d.draft)-1 {
+			if d.cursor < len(reveal)-1 {
 				d.cursor++
 			}
 		case "enter", " ":
-			if len(d.draft) > 0 && !m.hasDrafted(d.draft[d.cursor]) {
+			if len(reveal) > 0 && !m.hasDrafted(reveal[d.cursor]) {
 				m.actions <- game.DraftSelectionAction{Index: d.cursor}
-				if d.remaining > 0 {
-					d.remaining--
-				}
 			}
+		default:
+			m.useConsumable(msg.String(), -1)
 		}
 	}
 	return nil, nil
@@ -244,12 +473,12 @@ func (d *draftMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
 func (d *draftMode) View(m *model) string {
 	var b strings.Builder
 	b.WriteString(titleStyle.Render("Draftable Ingredients:") + "\n")
-	for i, ing := range d.draft {
+	for i, ing := range m.state.Reveal {
 		cursor := " "
 		if d.cursor == i {
 			cursor = ">"
 		}
-		line := fmt.Sprintf("%s %s (%s)", cursor, ing.Name, ing.Role)
+		line := fmt.Sprintf("%s %s (%s)%s", cursor, ing.Name, ing.Role, ingredientDetails(ing))
 		if m.hasDrafted(ing) {
 			line = disabledStyle.Render(line)
 		} else if d.cursor == i {
@@ -261,12 +490,12 @@ func (d *draftMode) View(m *model) string {
 }
 
 func (d *draftMode) Status(m *model) string {
-	if d.remaining < 0 {
+	if len(m.state.Reveal) == 0 {
 		return "Revealing ingredients..."
 	}
 	return fmt.Sprintf(
-		"Pick %d more ingredients • up/down: move • enter/space: draft • q: quit",
-		d.remaining,
+		"Pick %d more ingredients • up/down: move • enter/space: draft • 1-%d: use consumable • q: quit",
+		m.state.Picks, player.MaxConsumables,
 	)
 }
 
@@ -279,17 +508,10 @@ const (
 	focusDishes
 )
 
-type createdDish struct {
-	name  string
-	index int
-}
-
 type designMode struct {
-	drafted       []ingredient.Ingredient
 	cursor        int
 	selected      map[int]bool
 	name          textinput.Model
-	dishes        []createdDish
 	focus         designFocus
 	confirm       bool
 	deleteConfirm bool
@@ -302,7 +524,6 @@ func (d *designMode) Init(m *model) tea.Cmd {
 	d.name = textinput.New()
 	d.name.Placeholder = "Dish name"
 	d.name.Blur()
-	d.dishes = []createdDish{}
 	d.focus = focusIngredients
 	d.confirm = false
 	d.deleteConfirm = false
@@ -320,9 +541,16 @@ func (d *designMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
 		}
 		d.name, cmd = d.name.Update(msg)
 	}
+	created := d.created(m)
 	switch msg := msg.(type) {
+	case game.StateSnapshotEvent:
+		if d.dishCursor >= len(created) && d.dishCursor > 0 {
+			d.dishCursor = max(len(created)-1, 0)
+		}
+		if d.autoName {
+			d.name.SetValue(defaultDishName(d.selected, m.state.Drafted))
+		}
 	case game.DishCreatedEvent:
-		d.dishes = append(d.dishes, createdDish{name: msg.Dish.Name, index: len(m.dishes) - 1})
 		m.message = fmt.Sprintf("Added dish '%s'!", msg.Dish.Name)
 		d.name.SetValue("")
 		d.selected = make(map[int]bool)
@@ -330,20 +558,12 @@ func (d *designMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
 		d.autoName = true
 	case game.DishDeletedEvent:
 		m.message = fmt.Sprintf("Deleted dish '%s'", msg.Dish.Name)
-		for i, dd := range d.dishes {
-			if dd.index == msg.Index {
-				d.dishes = append(d.dishes[:i], d.dishes[i+1:]...)
-				if d.dishCursor >= len(d.dishes) && d.dishCursor > 0 {
-					d.dishCursor--
-				}
-				break
-			}
-		}
 		d.confirm = false
 		d.deleteConfirm = false
-	case game.ServiceResultEvent:
-		return &serviceMode{current: &msg}, nil
 	case tea.KeyMsg:
+		if d.focus != focusName && m.useConsumable(msg.String(), d.cursor) {
+			return nil, cmd
+		}
 		if msg.String() != "enter" {
 			d.confirm = false
 		}
@@ -364,9 +584,9 @@ func (d *designMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
 				d.dishCursor--
 			}
 		case "down", "j":
-			if d.focus == focusIngredients && d.cursor < len(d.drafted)-1 {
+			if d.focus == focusIngredients && d.cursor < len(m.state.Drafted)-1 {
 				d.cursor++
-			} else if d.focus == focusDishes && d.dishCursor < len(d.dishes)-1 {
+			} else if d.focus == focusDishes && d.dishCursor < len(created)-1 {
 				d.dishCursor++
 			}
 		case "enter":
@@ -379,10 +599,10 @@ func (d *designMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
 					m.message = fmt.Sprintf("each dish can have up to %d ingredients", dish.MaxIngredients)
 				}
 				if d.autoName {
-					d.name.SetValue(defaultDishName(d.selected, d.drafted))
+					d.name.SetValue(defaultDishName(d.selected, m.state.Drafted))
 				}
 			} else if d.focus == focusName {
-				if len(m.dishes) >= 10 || len(d.dishes) >= 2 {
+				if m.state.DishesLeft == 0 {
 					if !d.confirm {
 						d.confirm = true
 						m.message = "dish limit reached. press enter again to finish"
@@ -398,14 +618,9 @@ func (d *designMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
 					if len(d.selected) == 0 {
 						m.message = "select at least one ingredient to create a dish!"
 					} else {
-						var indices []int
-						for i := range d.drafted {
-							if d.selected[i] {
-								indices = append(indices, i)
-							}
-						}
+						indices := d.indices(m)
 						if name == "" {
-							name = defaultDishName(d.selected, d.drafted)
+							name = defaultDishName(d.selected, m.state.Drafted)
 						}
 						m.actions <- game.CreateDishAction{Name: name, Indices: indices}
 						m.message = ""
@@ -414,13 +629,12 @@ func (d *designMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
 				}
 			}
 		case "d", "D":
-			if d.focus == focusDishes && len(d.dishes) > 0 {
+			if d.focus == focusDishes && len(created) > 0 {
 				if !d.deleteConfirm {
 					d.deleteConfirm = true
-					m.message = fmt.Sprintf("press d again to delete '%s'", d.dishes[d.dishCursor].name)
+					m.message = fmt.Sprintf("press d again to delete '%s'", m.state.Menu[created[d.dishCursor]].Dish.Name)
 				} else {
-					idx := d.dishes[d.dishCursor].index
-					m.actions <- game.DeleteDishAction{Index: idx}
+					m.actions <- game.DeleteDishAction{Index: created[d.dishCursor]}
 					m.message = ""
 					d.deleteConfirm = false
 				}
@@ -446,10 +660,33 @@ func (d *designMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
 	return nil, cmd
 }
 
+// created returns the positions in the menu of the dishes designed this turn,
+// which are the ones that may be deleted.
+func (d *designMode) created(m *model) []int {
+	var out []int
+	for i, item := range m.state.Menu {
+		if item.New {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// indices returns the selected drafted ingredients' indices in order.
+func (d *designMode) indices(m *model) []int {
+	var out []int
+	for i := range m.state.Drafted {
+		if d.selected[i] {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
 func (d *designMode) View(m *model) string {
 	var b strings.Builder
 	b.WriteString(titleStyle.Render("Design Dishes") + "\n")
-	for i, ing := range d.drafted {
+	for i, ing := range m.state.Drafted {
 		cursor := " "
 		if d.cursor == i && d.focus == focusIngredients {
 			cursor = ">"
@@ -458,17 +695,17 @@ func (d *designMode) View(m *model) string {
 		if d.selected[i] {
 			mark = "*"
 		}
-		line := fmt.Sprintf("%s%s %s (%s)", cursor, mark, ing.Name, ing.Role)
+		line := fmt.Sprintf("%s%s %s (%s)%s", cursor, mark, ing.Name, ing.Role, ingredientDetails(ing))
 		if (d.cursor == i && d.focus == focusIngredients) || d.selected[i] {
 			line = selectedStyle.Render(line)
 		}
 		b.WriteString(line + "\n")
 	}
-	if len(d.dishes) > 0 {
+	if created := d.created(m); len(created) > 0 {
 		b.WriteString("\nDishes:\n")
-		for i, dd := range d.dishes {
+		for i, idx := range created {
 			cursor := " "
-			line := dd.name
+			line := m.state.Menu[idx].Dish.Name
 			if d.focus == focusDishes && d.dishCursor == i {
 				cursor = ">"
 				line = selectedStyle.Render(line)
@@ -476,30 +713,105 @@ func (d *designMode) View(m *model) string {
 			b.WriteString(fmt.Sprintf("%s %s\n", cursor, line))
 		}
 	}
+	if len(d.selected) > 0 {
+		p := m.state.Preview(d.indices(m))
+		b.WriteString(fmt.Sprintf("\nSelected: $%d", p.Dish.Cost()))
+		if f := p.Dish.Flavor(); f.Total() > 0 {
+			b.WriteString(fmt.Sprintf(" • %s", f))
+		}
+		if p.Synergy != 0 {
+			b.WriteString(fmt.Sprintf(" • synergy %+d", p.Synergy))
+		}
+		if p.Archetype != dish.NoArchetype {
+			b.WriteString(fmt.Sprintf(" • %s %+d", p.Archetype, p.Archetype.Bonus()))
+		}
+		if p.Recipe != "" {
+			b.WriteString(" • recipe: " + p.Recipe)
+		}
+		b.WriteString("\n")
+	}
+	if len(m.state.Peeked) > 0 {
+		b.WriteString("\nUpcoming customers:\n")
+		for _, c := range m.state.Peeked {
+			b.WriteString("  " + customerSummary(c) + "\n")
+		}
+	}
 	b.WriteString("\n" + d.name.View() + "\n")
 	return paneStyle.Render(b.String())
 }
 
 func (d *designMode) Status(m *model) string {
-	return "up/down: move • enter: select • tab: cycle ingredients/name/dish • enter x2: create dish • d x2: delete dish • f: finish • q: quit"
+	return fmt.Sprintf(
+		"up/down: move • enter: select • tab: cycle ingredients/name/dish • enter x2: create dish • d x2: delete dish • 1-%d: use consumable • f: finish • q: quit",
+		player.MaxConsumables,
+	)
+}
+
+// ingredientDetails formats an ingredient's optional attributes for display,
+// e.g. " • $2 Rare Japanese • salty 1, umami 2". It returns "" when the
+// ingredient has no attributes.
+func ingredientDetails(ing ingredient.Ingredient) string {
+	var attrs []string
+	if ing.Cost > 0 {
+		attrs = append(attrs, fmt.Sprintf("$%d", ing.Cost))
+	}
+	if ing.Rarity != "" {
+		attrs = append(attrs, string(ing.Rarity))
+	}
+	if ing.Cuisine != "" {
+		attrs = append(attrs, ing.Cuisine)
+	}
+	var out string
+	if len(attrs) > 0 {
+		out = " • " + strings.Join(attrs, " ")
+	}
+	if ing.Flavor.Total() > 0 {
+		out += " • " + ing.Flavor.String()
+	}
+	return out
 }
 
+// customerSummary describes a customer's name, cravings and constraint on one line.
+func customerSummary(c customer.Customer) string {
+	var cravings []string
+	for _, cr := range c.Cravings {
+		var names []string
+		for _, ing := range cr.Ingredients {
+			names = append(names, ing.Name)
+		}
+		cravings = append(cravings, strings.Join(names, "+"))
+	}
+	line := fmt.Sprintf("%s: %s", c.Name, strings.Join(cravings, " > "))
+	if c.Constraint != nil {
+		line += fmt.Sprintf(" (no %s)", c.Constraint.Name)
+	}
+	return line
+}
+
+// defaultDishName suggests a name for the selected ingredients based on the
+// archetype they form, e.g. "Beef Plate", "Chicken and Rice Bowl" or
+// "Lettuce Salad".
 func defaultDishName(selected map[int]bool, drafted []ingredient.Ingredient) string {
+	var ings []ingredient.Ingredient
 	var names []string
-	allVegetables := true
-	count := 0
 	for i := range drafted {
 		if selected[i] {
-			if drafted[i].Role != ingredient.Vegetable {
-				allVegetables = false
-			}
-			count++
+			ings = append(ings, drafted[i])
 			if len(names) < 2 {
 				names = append(names, drafted[i].Name)
 			}
 		}
 	}
-	if count > 1 && allVegetables {
+	switch dish.Classify(ings) {
+	case dish.Plate:
+		for _, ing := range ings {
+			if ing.Role == ingredient.Protein {
+				return ing.Name + " Plate"
+			}
+		}
+	case dish.Bowl:
+		return strings.Join(names, " and ") + " Bowl"
+	case dish.Salad:
 		return names[0] + " Salad"
 	}
 	switch len(names) {
@@ -513,7 +825,6 @@ func defaultDishName(selected map[int]bool, drafted []ingredient.Ingredient) str
 
 // ---- Service Mode ----
 type serviceMode struct {
-	current  *game.ServiceResultEvent
 	finished bool
 }
 
@@ -524,18 +835,16 @@ func (s *serviceMode) Init(m *model) tea.Cmd {
 
 func (s *serviceMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
 	switch msg := msg.(type) {
-	case game.ServiceResultEvent:
-		s.current = &msg
 	case game.ServiceEndEvent:
 		s.finished = true
-	case game.DraftOptionsEvent:
-		return &draftMode{draft: msg.Reveal, remaining: msg.Picks}, nil
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return nil, tea.Quit
 		case "enter":
 			m.actions <- game.ContinueAction{}
+		default:
+			m.useConsumable(msg.String(), -1)
 		}
 	}
 	return nil, nil
@@ -544,41 +853,29 @@ func (s *serviceMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
 func (s *serviceMode) View(m *model) string {
 	var b strings.Builder
 	b.WriteString(titleStyle.Render("Service") + "\n")
-	if s.current != nil {
+	if r := m.state.Result; r != nil {
 		var constraint string
-		if s.current.Customer.Constraint != nil {
-			constraint = fmt.Sprintf(" (no %s)", s.current.Customer.Constraint.Name)
-		}
-
-		// Determine which craving was fulfilled by the served dish.
-		fulfilled := 0
-		bestScore := 0
-		if s.current.Dish != nil {
-			fulfilled = -1
-			for i, cr := range s.current.Customer.Cravings {
-				score := 0
-				for _, ing := range cr.Ingredients {
-					for _, ding := range s.current.Dish.Ingredients {
-						if ding == ing {
-							score++
-							break
-						}
-					}
-				}
-				if score > bestScore {
-					bestScore = score
-					fulfilled = i
-				}
-			}
+		if r.Customer.Constraint != nil {
+			constraint = fmt.Sprintf(" (no %s)", r.Customer.Constraint.Name)
+		}
+		if r.Customer.Boss != nil {
+			constraint += fmt.Sprintf(" [Boss: %s]", r.Customer.Boss.Description)
+		}
+
+		// Highlight the craving the served dish was graded against, or the
+		// first craving when nothing was served.
+		fulfilled := r.Craving
+		if r.Dish == nil {
+			fulfilled = 0
 		}
 
-		b.WriteString(fmt.Sprintf("%s%s\n", s.current.Customer.Name, constraint))
-		for i, cr := range s.current.Customer.Cravings {
+		b.WriteString(fmt.Sprintf("%s%s\n", r.Customer.Name, constraint))
+		for i, cr := range r.Customer.Cravings {
 			var craving []string
 			for _, ing := range cr.Ingredients {
 				name := ing.Name
-				if i == fulfilled && s.current.Dish != nil {
-					for _, ding := range s.current.Dish.Ingredients {
+				if i == fulfilled && r.Dish != nil {
+					for _, ding := range r.Dish.Ingredients {
 						if ding == ing {
 							name = servedStyle.Render(name)
 							break
@@ -589,13 +886,30 @@ func (s *serviceMode) View(m *model) string {
 			}
 			b.WriteString(strings.Join(craving, ", "))
 			if i == fulfilled {
-				if s.current.Dish != nil {
-					b.WriteString(" -> " + servedStyle.Render(s.current.Dish.Name))
+				if r.Dish != nil && r.Player != m.player {
+					b.WriteString(fmt.Sprintf(" -> player %d's %s", r.Player, r.Dish.Name))
+				} else if r.Dish != nil {
+					b.WriteString(" -> " + servedStyle.Render(r.Dish.Name))
 				} else {
 					b.WriteString(" -> " + missingStyle.Render("no dish"))
 				}
-				if s.current.Payment > 0 {
-					b.WriteString(fmt.Sprintf(" ($%d)", s.current.Payment))
+				if r.Payment > 0 {
+					b.WriteString(fmt.Sprintf(" ($%d)", r.Payment))
+				}
+				if r.Satisfaction == game.Partial {
+					b.WriteString(" " + missingStyle.Render("partial"))
+				}
+				if r.Penalty > 0 {
+					b.WriteString(fmt.Sprintf(" %d extra -$%d", r.Extras, r.Penalty))
+				}
+				if r.Synergy != 0 {
+					b.WriteString(fmt.Sprintf(" synergy %+d", r.Synergy))
+				}
+				if r.Archetype != dish.NoArchetype {
+					b.WriteString(fmt.Sprintf(" %s %+d", strings.ToLower(string(r.Archetype)), r.Archetype.Bonus()))
+				}
+				if r.Recipe != "" {
+					b.WriteString(fmt.Sprintf(" (%s recipe)", r.Recipe))
 				}
 			}
 			b.WriteString("\n")
@@ -608,7 +922,39 @@ func (s *serviceMode) Status(m *model) string {
 	if s.finished {
 		return "enter: next turn • q: quit"
 	}
-	return "enter: next customer • q: quit"
+	return fmt.Sprintf("enter: next customer • 1-%d: use consumable • q: quit", player.MaxConsumables)
+}
+
+// ---- Game Over Mode ----
+type gameOverMode struct {
+	event game.GameOverEvent
+}
+
+func (g *gameOverMode) Init(m *model) tea.Cmd {
+	m.message = ""
+	return nil
+}
+
+func (g *gameOverMode) Update(m *model, msg tea.Msg) (uiMode, tea.Cmd) {
+	if km, ok := msg.(tea.KeyMsg); ok {
+		switch km.String() {
+		case "ctrl+c", "q", "enter":
+			return nil, tea.Quit
+		}
+	}
+	return nil, nil
+}
+
+func (g *gameOverMode) View(m *model) string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Game Over") + "\n")
+	b.WriteString(fmt.Sprintf("You %s.\n", g.event.Reason))
+	b.WriteString(fmt.Sprintf("Reached ante %d on turn %d with $%d.\n", g.event.Ante, g.event.Turn, g.event.Money))
+	return paneStyle.Render(b.String())
+}
+
+func (g *gameOverMode) Status(m *model) string {
+	return "enter/q: quit"
 }
 
 var (
@@ -624,8 +970,11 @@ var (
 )
 
 // Run renders game events and sends player actions back to the game.
-func Run(events <-chan game.Event, actions chan<- game.Action) error {
+func Run(events <-chan game.Event, actions chan<- game.Action, opts ...Option) error {
 	m := initialModel(actions)
+	for _, opt := range opts {
+		opt(m)
+	}
 	p := tea.NewProgram(m, tea.WithAltScreen())
 
 	go func() {
@@ -1,36 +1,217 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
 
-	"executive-chef/internal/customer"
-	"executive-chef/internal/deck"
-	"executive-chef/internal/game"
-	"executive-chef/internal/ingredient"
-	"executive-chef/internal/player"
+	"executive-chef/internal/bot"
+	"executive-chef/internal/extbot"
+	"executive-chef/internal/headless"
+	"executive-chef/internal/netplay"
+	"executive-chef/internal/rng"
 	"executive-chef/internal/ui"
 )
 
 func main() {
-	ingredients, err := ingredient.LoadFromFile("ingredients.yaml")
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "validate":
+			os.Exit(runValidate(os.Args[2:], os.Stdout))
+		case "headless":
+			runHeadless(os.Args[2:])
+			return
+		case "autoplay":
+			runAutoplay(os.Args[2:])
+			return
+		case "simulate":
+			os.Exit(runSimulate(os.Args[2:], os.Stdout))
+		case "tournament":
+			os.Exit(runTournament(os.Args[2:], os.Stdout))
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		case "join":
+			runJoin(os.Args[2:])
+			return
+		}
+	}
+
+	runPlay(os.Args[1:])
+}
+
+// runPlay plays a game in the TUI.
+func runPlay(args []string) {
+	fs := flag.NewFlagSet("play", flag.ExitOnError)
+	rec := recordFlag(fs)
+	trace := traceFlag(fs)
+	fs.Parse(args)
+
+	g, err := newGame()
 	if err != nil {
 		log.Fatal(err)
 	}
+	stop, err := startTrace(g, *trace)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stop()
+	if err := watch(g, *rec); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	d := deck.New(ingredients)
-	c := customer.NewDeck(ingredients)
-	p := player.New()
+// botFlag registers the -bot flag shared by the bot-driven subcommands.
+func botFlag(fs *flag.FlagSet) *string {
+	return fs.String("bot", "heuristic", "bot to play with ("+strings.Join(bot.Names(), ", ")+")")
+}
 
-	events := make(chan game.Event)
-	actions := make(chan game.Action)
+// runAutoplay shows a bot playing a game in the TUI.
+func runAutoplay(args []string) {
+	fs := flag.NewFlagSet("autoplay", flag.ExitOnError)
+	name := botFlag(fs)
+	delay := fs.Duration("delay", 400*time.Millisecond, "pause between the bot's actions")
+	rec := recordFlag(fs)
+	trace := traceFlag(fs)
+	fs.Parse(args)
 
-	g := game.New(d, c, p, events, actions)
+	s, err := bot.New(*name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g, err := newGame()
+	if err != nil {
+		log.Fatal(err)
+	}
+	stop, err := startTrace(g, *trace)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stop()
+
+	if err := watch(g, *rec, ui.WithAutoplay(s, *delay)); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	go g.Play()
+// runHeadless plays a single game without the TUI and prints the outcome.
+// With -players, every seat at the table is played by its own copy of the bot,
+// or by its own copy of the -exec program.
+func runHeadless(args []string) {
+	fs := flag.NewFlagSet("headless", flag.ExitOnError)
+	name := botFlag(fs)
+	players := fs.Int("players", 1, "number of players at the table")
+	command := fs.String("exec", "", "external bot program to play with, speaking JSON lines on stdin/stdout")
+	timeout := fs.Duration("timeout", extbot.DefaultTimeout, "time an -exec bot has for each decision")
+	trace := traceFlag(fs)
+	fs.Parse(args)
 
-	if err := ui.Run(events, actions); err != nil {
+	if *players < 1 {
+		log.Fatalf("need at least one player, got %d", *players)
+	}
+	strategies := make([]headless.Strategy, *players)
+	for i := range strategies {
+		if *command != "" {
+			b, err := extbot.Start(strings.Fields(*command), *timeout)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer b.Close()
+			strategies[i] = b
+			continue
+		}
+		s, err := bot.New(*name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		strategies[i] = s
+	}
+	rs, err := loadRuleSet(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+	g, err := rs.newTable(rng.Global(), *players)
+	if err != nil {
 		log.Fatal(err)
 	}
+	stop, err := startTrace(g, *trace)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stop()
+	results, err := headless.RunTable(g, strategies)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for id, res := range results {
+		if *players > 1 {
+			fmt.Printf("Player %d: ", id)
+		}
+		fmt.Printf("Game over on turn %d (ante %d) with $%d: %s\n", res.Turns, res.Ante, res.Money, res.Reason)
+	}
+}
+
+// runServe hosts a game over TCP and waits for a client to join every seat.
+// Players who disconnect are replaced by the -bot strategy.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":7777", "address to listen on")
+	players := fs.Int("players", 2, "number of players at the table")
+	rules := fs.String("rules", ".", "directory of content files")
+	name := botFlag(fs)
+	trace := traceFlag(fs)
+	fs.Parse(args)
+
+	if *players < 1 {
+		log.Fatalf("need at least one player, got %d", *players)
+	}
+	if _, err := bot.New(*name); err != nil {
+		log.Fatal(err)
+	}
+	rs, err := loadRuleSet(*rules)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g, err := rs.newTable(rng.Global(), *players)
+	if err != nil {
+		log.Fatal(err)
+	}
+	stop, err := startTrace(g, *trace)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stop()
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("waiting for %d players on %s", *players, l.Addr())
+	s := &netplay.Server{Substitute: func() headless.Strategy {
+		b, _ := bot.New(*name)
+		return b
+	}}
+	if err := s.Serve(l, g); err != nil {
+		log.Fatal(err)
+	}
+	log.Print("game over")
+}
 
-	// Game ended
+// runJoin plays a game hosted with serve in the TUI.
+func runJoin(args []string) {
+	fs := flag.NewFlagSet("join", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:7777", "address of the server")
+	fs.Parse(args)
+
+	c, err := netplay.Dial(*addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer c.Close()
+	if err := ui.Run(c.Events, c.Actions, ui.WithPlayer(c.Player)); err != nil {
+		log.Fatal(err)
+	}
 }
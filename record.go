@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"executive-chef/internal/bus"
+	"executive-chef/internal/game"
+	"executive-chef/internal/ui"
+)
+
+// recordQueue is the number of events a recorder may fall behind before
+// events are dropped from the recording rather than holding up the game.
+const recordQueue = 1024
+
+// recordFlag registers the -record flag shared by the TUI subcommands.
+func recordFlag(fs *flag.FlagSet) *string {
+	return fs.String("record", "", "file to record every event to as JSON lines")
+}
+
+// watch plays g in the TUI. The game's events are published on a bus that
+// the TUI subscribes to, and so does a recorder writing them to recordPath
+// when it is set.
+func watch(g *game.Game, recordPath string, opts ...ui.Option) error {
+	events := make(chan game.Event)
+	actions := make(chan game.Action)
+	g.Events = events
+	g.Actions = actions
+
+	b := bus.New()
+	view := b.Subscribe(0, bus.Block)
+	var recorded chan error
+	if recordPath != "" {
+		f, err := os.Create(recordPath)
+		if err != nil {
+			return err
+		}
+		sub := b.Subscribe(recordQueue, bus.DropNewest)
+		recorded = make(chan error, 1)
+		go func() { recorded <- record(f, sub) }()
+	}
+	go b.Forward(events)
+	go g.Play()
+
+	err := ui.Run(view.C, actions, opts...)
+	b.Close()
+	if recorded != nil {
+		if rerr := <-recorded; err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
+
+// record writes the events of sub to f as codec JSON lines until sub is
+// closed, then closes f.
+func record(f *os.File, sub *bus.Subscription) error {
+	w := bufio.NewWriter(f)
+	for e := range sub.C {
+		data, err := game.MarshalEvent(e)
+		if err != nil {
+			continue
+		}
+		w.Write(data)
+		w.WriteByte('\n')
+	}
+	if n := sub.Dropped(); n > 0 {
+		log.Printf("recording fell behind and dropped %d events", n)
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("recording: %w", err)
+	}
+	return f.Close()
+}
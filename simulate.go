@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"executive-chef/internal/bot"
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+	"executive-chef/internal/sim"
+)
+
+// runSimulate plays many seeded headless games with a bot and prints a
+// balance report. It returns the process exit code.
+func runSimulate(args []string, out io.Writer) int {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	fs.SetOutput(out)
+	games := fs.Int("games", 1000, "number of games to play")
+	seed := fs.Int64("seed", 1, "seed of the first game")
+	workers := fs.Int("workers", 0, "games played at once (0 for one per CPU)")
+	rules := fs.String("rules", ".", "directory holding the rule set's content files")
+	name := botFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if _, err := bot.New(*name); err != nil {
+		fmt.Fprintln(out, err)
+		return 2
+	}
+	rs, err := loadRuleSet(*rules)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	report, err := sim.Run(sim.Config{
+		Games:   *games,
+		Seed:    *seed,
+		Workers: *workers,
+		NewGame: func(r *rand.Rand) (*game.Game, error) { return rs.newGame(r) },
+		NewStrategy: func() headless.Strategy {
+			s, _ := bot.New(*name)
+			return s
+		},
+	})
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	fmt.Fprintf(out, "Bot: %s, rules: %s\n", *name, *rules)
+	if err := report.Write(out); err != nil {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	"executive-chef/internal/bot"
+	"executive-chef/internal/game"
+	"executive-chef/internal/headless"
+	"executive-chef/internal/tournament"
+)
+
+// runTournament plays every listed bot on the same seeded games and prints
+// their ratings. It returns the process exit code.
+func runTournament(args []string, out io.Writer) int {
+	fs := flag.NewFlagSet("tournament", flag.ContinueOnError)
+	fs.SetOutput(out)
+	bots := fs.String("bots", strings.Join(bot.Names(), ","), "comma-separated bots to compare")
+	games := fs.Int("games", 500, "number of seeds every bot plays")
+	seed := fs.Int64("seed", 1, "seed of the first game")
+	workers := fs.Int("workers", 0, "games played at once (0 for one per CPU)")
+	rules := fs.String("rules", ".", "directory holding the rule set's content files")
+	resamples := fs.Int("resamples", tournament.DefaultResamples, "bootstrap resamples for confidence intervals")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var entrants []tournament.Entrant
+	for _, name := range strings.Split(*bots, ",") {
+		name = strings.TrimSpace(name)
+		if _, err := bot.New(name); err != nil {
+			fmt.Fprintln(out, err)
+			return 2
+		}
+		entrants = append(entrants, tournament.Entrant{
+			Name: name,
+			NewStrategy: func() headless.Strategy {
+				s, _ := bot.New(name)
+				return s
+			},
+		})
+	}
+	rs, err := loadRuleSet(*rules)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	standings, err := tournament.Run(tournament.Config{
+		Entrants:  entrants,
+		Games:     *games,
+		Seed:      *seed,
+		Workers:   *workers,
+		NewGame:   func(r *rand.Rand) (*game.Game, error) { return rs.newGame(r) },
+		Resamples: *resamples,
+	})
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	fmt.Fprintf(out, "%d games per bot (seeds %d-%d), rules: %s\n", *games, *seed, *seed+int64(*games)-1, *rules)
+	if err := tournament.WriteStandings(out, standings); err != nil {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+
+	"executive-chef/internal/game"
+)
+
+// traceFlag registers the -trace flag shared by the subcommands that play a
+// single game.
+func traceFlag(fs *flag.FlagSet) *string {
+	return fs.String("trace", "", "file to write a JSON debug trace of engine decisions to")
+}
+
+// startTrace points g's debug trace at a new file at path, when it is set,
+// and returns a function closing the file. Records are written as they
+// happen, so a trace survives the game exiting early.
+func startTrace(g *game.Game, path string) (func(), error) {
+	if path == "" {
+		return func() {}, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	g.Trace = slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return func() { f.Close() }, nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"executive-chef/internal/deck"
+	"executive-chef/internal/game"
+	"executive-chef/internal/ingredient"
+	"executive-chef/internal/pairing"
+	"executive-chef/internal/recipe"
+	"executive-chef/internal/rng"
+)
+
+// contentCheck checks the content file at path and reports what it holds.
+// known loads the ingredients.yaml in the file's directory, for files that
+// name ingredients.
+type contentCheck func(path string, known func() ([]ingredient.Ingredient, error)) (string, error)
+
+// contentChecks check the content files other than ingredient files, keyed
+// by file name.
+var contentChecks = map[string]contentCheck{
+	"deck.yaml": func(path string, known func() ([]ingredient.Ingredient, error)) (string, error) {
+		ings, err := known()
+		if err != nil {
+			return "", err
+		}
+		r, err := deck.LoadRecipe(path)
+		if err != nil {
+			return "", err
+		}
+		if _, err := deck.BuildFrom(rng.New(0), ings, r); err != nil {
+			return "", fmt.Errorf("%s: %w", path, err)
+		}
+		return fmt.Sprintf("%d cards", r.Size), nil
+	},
+	"pairings.yaml": func(path string, known func() ([]ingredient.Ingredient, error)) (string, error) {
+		ings, err := known()
+		if err != nil {
+			return "", err
+		}
+		t, err := pairing.LoadFromFile(path, ings)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d pairings", len(t.Pairings)), nil
+	},
+	"recipes.yaml": func(path string, known func() ([]ingredient.Ingredient, error)) (string, error) {
+		ings, err := known()
+		if err != nil {
+			return "", err
+		}
+		b, err := recipe.LoadFromFile(path, ings)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d recipes", len(b.Recipes)), nil
+	},
+	"payouts.yaml": func(path string, _ func() ([]ingredient.Ingredient, error)) (string, error) {
+		p, err := game.LoadPayouts(path)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d craving ranks", len(p.Ranks)), nil
+	},
+}
+
+// runValidate checks each content file named in args, defaulting to
+// ingredients.yaml, and prints every problem found. deck.yaml,
+// pairings.yaml, recipes.yaml and payouts.yaml are checked by their own
+// loaders; any other file is checked as an ingredient file. It returns the
+// process exit code: 0 when all files are valid and 1 otherwise.
+func runValidate(args []string, out io.Writer) int {
+	if len(args) == 0 {
+		args = []string{"ingredients.yaml"}
+	}
+	code := 0
+	for _, path := range args {
+		if check, ok := contentChecks[filepath.Base(path)]; ok {
+			if err := validateContent(path, check, out); err != nil {
+				fmt.Fprintln(out, err)
+				code = 1
+			}
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintln(out, err)
+			code = 1
+			continue
+		}
+		ings, problems, err := ingredient.Validate(path, data)
+		if err != nil {
+			fmt.Fprintln(out, err)
+			code = 1
+			continue
+		}
+		for _, p := range problems {
+			fmt.Fprintln(out, p.Error())
+		}
+		if len(problems) > 0 {
+			code = 1
+			continue
+		}
+		fmt.Fprintf(out, "%s: ok (%d ingredients)\n", path, len(ings))
+	}
+	return code
+}
+
+// validateContent runs check on the content file at path and prints its
+// summary.
+func validateContent(path string, check contentCheck, out io.Writer) error {
+	known := func() ([]ingredient.Ingredient, error) {
+		ings, err := ingredient.LoadFromFile(filepath.Join(filepath.Dir(path), "ingredients.yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: loading its ingredients: %w", path, err)
+		}
+		return ings, nil
+	}
+	summary, err := check(path, known)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s: ok (%s)\n", path, summary)
+	return nil
+}